@@ -27,6 +27,7 @@ require (
 	github.com/xtgo/uuid v0.0.0-20140804021211-a0b114877d4c // indirect
 	go.mongodb.org/mongo-driver v1.5.1
 	gopkg.in/segmentio/analytics-go.v3 v3.1.0
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 replace github.com/edaniels/golinters => github.com/mongodb-forks/golinters v0.0.4
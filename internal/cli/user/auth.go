@@ -1,9 +1,17 @@
 package user
 
 import (
+	"fmt"
+	"os"
 	"strings"
 )
 
+// set of environment variables holding the user credentials
+const (
+	EnvPublicAPIKey  = "REALM_PUBLIC_API_KEY"
+	EnvPrivateAPIKey = "REALM_PRIVATE_API_KEY"
+)
+
 // Session is the CLI profile session
 type Session struct {
 	AccessToken  string
@@ -16,6 +24,26 @@ type Credentials struct {
 	PrivateAPIKey string
 }
 
+// CredentialsFromEnv reads the user credentials from the environment,
+// which allows authenticating non-interactively (e.g. in CI)
+func CredentialsFromEnv() (Credentials, error) {
+	var creds Credentials
+	for _, env := range []struct {
+		name  string
+		value *string
+	}{
+		{EnvPublicAPIKey, &creds.PublicAPIKey},
+		{EnvPrivateAPIKey, &creds.PrivateAPIKey},
+	} {
+		value := os.Getenv(env.name)
+		if value == "" {
+			return Credentials{}, fmt.Errorf("missing environment variable: %s", env.name)
+		}
+		*env.value = value
+	}
+	return creds, nil
+}
+
 // RedactedPrivateAPIKey returns the user's private API key with sensitive information redacted
 func (creds Credentials) RedactedPrivateAPIKey() string {
 	redact := func(s string) string {
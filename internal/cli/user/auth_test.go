@@ -0,0 +1,54 @@
+package user
+
+import (
+	"os"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestCredentialsFromEnv(t *testing.T) {
+	setEnv := func(t *testing.T, publicAPIKey, privateAPIKey string) {
+		t.Helper()
+		for name, value := range map[string]string{
+			EnvPublicAPIKey:  publicAPIKey,
+			EnvPrivateAPIKey: privateAPIKey,
+		} {
+			name := name
+			prev, ok := os.LookupEnv(name)
+			assert.Nil(t, os.Setenv(name, value))
+			t.Cleanup(func() {
+				if ok {
+					os.Setenv(name, prev)
+				} else {
+					os.Unsetenv(name)
+				}
+			})
+		}
+	}
+
+	t.Run("should read the credentials from the environment", func(t *testing.T) {
+		setEnv(t, "public", "private")
+
+		creds, err := CredentialsFromEnv()
+		assert.Nil(t, err)
+		assert.Equal(t, Credentials{PublicAPIKey: "public", PrivateAPIKey: "private"}, creds)
+	})
+
+	for _, tc := range []struct {
+		description   string
+		publicAPIKey  string
+		privateAPIKey string
+		missing       string
+	}{
+		{"should fail without a public api key", "", "private", EnvPublicAPIKey},
+		{"should fail without a private api key", "public", "", EnvPrivateAPIKey},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			setEnv(t, tc.publicAPIKey, tc.privateAPIKey)
+
+			_, err := CredentialsFromEnv()
+			assert.Equal(t, "missing environment variable: "+tc.missing, err.Error())
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package user
+
+// CredentialStore persists the user session, which allows storing
+// the session tokens somewhere other than the CLI profile file
+// (e.g. the OS keychain)
+type CredentialStore interface {
+	Load() (Session, error)
+	Save(session Session) error
+	Clear() error
+}
+
+// NewProfileCredentialStore creates a credential store persisting
+// the user session to the CLI profile file
+func NewProfileCredentialStore(profile *Profile) CredentialStore {
+	return profileCredentialStore{profile}
+}
+
+type profileCredentialStore struct {
+	profile *Profile
+}
+
+func (s profileCredentialStore) Load() (Session, error) {
+	return s.profile.Session(), nil
+}
+
+func (s profileCredentialStore) Save(session Session) error {
+	s.profile.SetSession(session)
+	return s.profile.Save()
+}
+
+func (s profileCredentialStore) Clear() error {
+	s.profile.ClearSession()
+	return s.profile.Save()
+}
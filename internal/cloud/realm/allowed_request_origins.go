@@ -0,0 +1,77 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	allowedRequestOriginsPathPattern = appPathPattern + "/security/allowed_request_origins"
+)
+
+func (c *client) AllowedRequestOrigins(groupID, appID string) ([]string, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(allowedRequestOriginsPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get allowed request origins", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var origins []string
+	if err := json.NewDecoder(res.Body).Decode(&origins); err != nil {
+		return nil, err
+	}
+	return origins, nil
+}
+
+func (c *client) UpdateAllowedRequestOrigins(groupID, appID string, origins []string) error {
+	for _, origin := range origins {
+		if err := validateRequestOrigin(origin); err != nil {
+			return err
+		}
+	}
+
+	if origins == nil {
+		origins = []string{}
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(allowedRequestOriginsPathPattern, groupID, appID),
+		origins,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update allowed request origins", res.StatusCode}
+	}
+	return nil
+}
+
+// validateRequestOrigin ensures the origin is a well-formed http(s) url
+// made of only a scheme, a host and an optional port (e.g. "https://example.com:8080")
+func validateRequestOrigin(origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil ||
+		(u.Scheme != "http" && u.Scheme != "https") ||
+		u.Host == "" ||
+		u.User != nil ||
+		(u.Path != "" && u.Path != "/") ||
+		u.RawQuery != "" ||
+		u.Fragment != "" {
+		return fmt.Errorf("invalid allowed request origin: '%s'", origin)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package realm
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestValidateRequestOrigin(t *testing.T) {
+	for _, origin := range []string{
+		"http://localhost",
+		"http://localhost:8080",
+		"https://example.com",
+		"https://example.com/",
+	} {
+		t.Run("should accept "+origin, func(t *testing.T) {
+			assert.Nil(t, validateRequestOrigin(origin))
+		})
+	}
+
+	for _, origin := range []string{
+		"",
+		"example.com",
+		"ftp://example.com",
+		"https://",
+		"https://user@example.com",
+		"https://example.com/path",
+		"https://example.com?q=1",
+		"https://example.com#fragment",
+		"https://exa mple.com",
+	} {
+		t.Run("should reject '"+origin+"'", func(t *testing.T) {
+			err := validateRequestOrigin(origin)
+			assert.Equal(t, "invalid allowed request origin: '"+origin+"'", err.Error())
+		})
+	}
+}
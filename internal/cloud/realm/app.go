@@ -1,11 +1,14 @@
 package realm
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
@@ -37,6 +40,7 @@ type App struct {
 	LastModified int64  `json:"last_modified"`
 	Product      string `json:"product"`
 	TemplateID   string `json:"template_id"`
+	Disabled     bool   `json:"disabled,omitempty"`
 }
 
 // Option returns the Realm app data displayed as a selectable option
@@ -49,7 +53,41 @@ type createAppRequest struct {
 	AppMeta
 }
 
+var (
+	// appNamePattern matches the names allowed for a Realm app
+	appNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	errAppNameMissing = errors.New("app name is required")
+	errInvalidAppName = errors.New("app names may only contain ASCII letters, numbers, underscores, and hyphens")
+)
+
+// ValidateCreateApp checks the name and metadata of an app to create, which gives immediate
+// feedback on invalid inputs without a round trip to the server; the server still validates
+// them when the app is created, so an app passing these checks may fail to be created
+func ValidateCreateApp(name string, meta AppMeta) error {
+	if name == "" {
+		return errAppNameMissing
+	}
+	if !appNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid app name '%s': %w", name, errInvalidAppName)
+	}
+	if !isValidLocation(meta.Location) {
+		return errInvalidLocation
+	}
+	if !isValidDeploymentModel(meta.DeploymentModel) {
+		return errInvalidDeploymentModel
+	}
+	if !isValidEnvironment(meta.Environment) {
+		return errInvalidEnvironment
+	}
+	return nil
+}
+
 func (c *client) CreateApp(groupID, name string, meta AppMeta) (App, error) {
+	if err := ValidateCreateApp(name, meta); err != nil {
+		return App{}, err
+	}
+
 	res, resErr := c.doJSON(
 		http.MethodPost,
 		fmt.Sprintf(appsPathPattern, groupID),
@@ -65,7 +103,7 @@ func (c *client) CreateApp(groupID, name string, meta AppMeta) (App, error) {
 	defer res.Body.Close()
 
 	var app App
-	if err := json.NewDecoder(res.Body).Decode(&app); err != nil {
+	if err := c.decodeStrictJSON(res.Body, &app); err != nil {
 		return App{}, err
 	}
 	return app, nil
@@ -86,6 +124,47 @@ func (c *client) DeleteApp(groupID, appID string) error {
 	return nil
 }
 
+type appStateRequest struct {
+	Disabled bool `json:"disabled"`
+}
+
+func (c *client) DisableApp(groupID, appID string) error {
+	return c.setAppState(groupID, appID, true)
+}
+
+func (c *client) EnableApp(groupID, appID string) error {
+	return c.setAppState(groupID, appID, false)
+}
+
+func (c *client) setAppState(groupID, appID string, disabled bool) error {
+	app, err := c.FindApp(groupID, appID)
+	if err != nil {
+		return err
+	}
+	if app.Disabled == disabled {
+		return ErrAppStateUnchanged{app.ClientAppID, disabled}
+	}
+
+	action := "enable app"
+	if disabled {
+		action = "disable app"
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPatch,
+		fmt.Sprintf(appPathPattern, groupID, appID),
+		appStateRequest{disabled},
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{action, res.StatusCode}
+	}
+	return nil
+}
+
 // TODO(REALMC-9462): remove this once /apps has "template_id" in the payload
 func (c *client) FindApp(groupID, appID string) (App, error) {
 	res, err := c.do(
@@ -102,12 +181,38 @@ func (c *client) FindApp(groupID, appID string) (App, error) {
 	defer res.Body.Close()
 
 	var app App
-	if err := json.NewDecoder(res.Body).Decode(&app); err != nil {
+	if err := c.decodeStrictJSON(res.Body, &app); err != nil {
 		return App{}, err
 	}
 	return app, nil
 }
 
+// FindAppByClientAppID finds the app with the provided client app id
+// by only searching the apps that belong to the provided group
+func (c *client) FindAppByClientAppID(groupID, clientAppID string) (App, error) {
+	apps, err := c.getApps(groupID, nil)
+	if err != nil {
+		if _, ok := err.(ErrAppsDecode); !ok {
+			return App{}, err
+		}
+	}
+
+	app, findErr := findAppByClientAppID(apps, clientAppID)
+	if findErr != nil && err != nil {
+		return App{}, err
+	}
+	return app, findErr
+}
+
+func findAppByClientAppID(apps []App, clientAppID string) (App, error) {
+	for _, app := range apps {
+		if app.ClientAppID == clientAppID {
+			return app, nil
+		}
+	}
+	return App{}, ErrAppNotFound{clientAppID}
+}
+
 // AppFilter represents the optional filter parameters available for lists of apps
 type AppFilter struct {
 	GroupID  string
@@ -126,22 +231,20 @@ var (
 
 func (c *client) FindApps(filter AppFilter) ([]App, error) {
 	var apps []App
+	var err error
 	if filter.GroupID == "" {
-		arr, err := c.getAppsForUser(filter.Products)
-		if err != nil {
-			return nil, err
-		}
-		apps = arr
+		apps, err = c.getAppsForUser(filter.Products)
 	} else {
-		arr, err := c.getApps(filter.GroupID, filter.Products)
-		if err != nil {
+		apps, err = c.getApps(filter.GroupID, filter.Products)
+	}
+	if err != nil {
+		if _, ok := err.(ErrAppsDecode); !ok {
 			return nil, err
 		}
-		apps = arr
 	}
 
 	if filter.App == "" {
-		return apps, nil
+		return apps, err
 	}
 
 	var filtered = make([]App, 0, len(apps))
@@ -150,7 +253,15 @@ func (c *client) FindApps(filter AppFilter) ([]App, error) {
 			filtered = append(filtered, app)
 		}
 	}
-	return filtered, nil
+	return filtered, err
+}
+
+func (c *client) ListAllApps() (map[string][]App, error) {
+	profile, profileErr := c.AuthProfile()
+	if profileErr != nil {
+		return nil, profileErr
+	}
+	return c.getAppsByGroup(profile.AllGroupIDs(), nil)
 }
 
 func (c *client) getAppsForUser(products []string) ([]App, error) {
@@ -159,28 +270,59 @@ func (c *client) getAppsForUser(products []string) ([]App, error) {
 		return nil, profileErr
 	}
 
+	groupIDs := profile.AllGroupIDs()
+
+	appsByGroup, err := c.getAppsByGroup(groupIDs, products)
+	if err != nil {
+		if _, ok := err.(ErrAppsDecode); !ok {
+			return nil, err
+		}
+	}
+
 	var apps []App
-	for _, groupID := range profile.AllGroupIDs() {
+	for _, groupID := range groupIDs {
+		apps = append(apps, appsByGroup[groupID]...)
+	}
+	return apps, err
+}
+
+// getAppsByGroup gets the apps of the provided groups keyed by group id, a group failing
+// to list its apps fails the call unless only some of its apps failed to decode
+func (c *client) getAppsByGroup(groupIDs, products []string) (map[string][]App, error) {
+	appsByGroup := make(map[string][]App, len(groupIDs))
+	var decodeErr ErrAppsDecode
+	for _, groupID := range groupIDs {
 		projectApps, err := c.getApps(groupID, products)
 		if err != nil {
-			return nil, err
+			if !decodeErr.merge(err) {
+				return nil, fmt.Errorf("failed to get apps for group '%s': %w", groupID, err)
+			}
 		}
-		apps = append(apps, projectApps...)
+		appsByGroup[groupID] = projectApps
 	}
-	return apps, nil
+	if len(decodeErr.Errors) > 0 {
+		return appsByGroup, decodeErr
+	}
+	return appsByGroup, nil
 }
 
 func (c *client) getApps(groupID string, products []string) ([]App, error) {
 	allProducts := resolveProducts(products)
 
 	var apps []App
+	var decodeErr ErrAppsDecode
 	for _, product := range allProducts {
 		productApps, err := c.getAppsForProduct(groupID, product)
 		if err != nil {
-			return nil, err
+			if !decodeErr.merge(err) {
+				return nil, err
+			}
 		}
 		apps = append(apps, productApps...)
 	}
+	if len(decodeErr.Errors) > 0 {
+		return apps, decodeErr
+	}
 	return apps, nil
 }
 
@@ -206,21 +348,88 @@ func (c *client) getAppsForProduct(groupID, product string) ([]App, error) {
 		url += "?product=" + product
 	}
 
-	res, err := c.do(http.MethodGet, url, api.RequestOptions{})
+	cached, isCached := c.cachedApps(url)
+
+	options := api.RequestOptions{}
+	if isCached {
+		options.Headers = map[string]string{api.HeaderIfNoneMatch: cached.etag}
+	}
+
+	res, err := c.do(http.MethodGet, url, options)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && isCached {
+		return append([]App(nil), cached.apps...), nil
+	}
 	if res.StatusCode == http.StatusNotFound {
 		return nil, errors.New("group could not be found")
 	}
 	if res.StatusCode != http.StatusOK {
 		return nil, api.ErrUnexpectedStatusCode{"get apps", res.StatusCode}
 	}
-	defer res.Body.Close()
 
-	var apps []App
-	if err := json.NewDecoder(res.Body).Decode(&apps); err != nil {
+	apps, err := c.decodeApps(res.Body)
+	if err == nil {
+		c.cacheApps(url, res.Header.Get(api.HeaderETag), apps)
+	}
+	return apps, err
+}
+
+// appsCacheEntry is a list of apps cached alongside its ETag
+type appsCacheEntry struct {
+	etag string
+	apps []App
+}
+
+func (c *client) cachedApps(url string) (appsCacheEntry, bool) {
+	c.appsCacheMu.Lock()
+	defer c.appsCacheMu.Unlock()
+
+	entry, ok := c.appsCache[url]
+	return entry, ok
+}
+
+// cacheApps caches the apps listed at the url, which only
+// happens when the server provided an ETag for them
+func (c *client) cacheApps(url, etag string, apps []App) {
+	c.appsCacheMu.Lock()
+	defer c.appsCacheMu.Unlock()
+
+	if etag == "" {
+		delete(c.appsCache, url)
+		return
+	}
+	if c.appsCache == nil {
+		c.appsCache = map[string]appsCacheEntry{}
+	}
+	c.appsCache[url] = appsCacheEntry{etag, append([]App(nil), apps...)}
+}
+
+// decodeApps decodes the list of apps one at a time so that a malformed app
+// does not prevent the others from being returned, in which case the apps
+// are returned alongside an ErrAppsDecode describing which ones failed
+func (c *client) decodeApps(r io.Reader) ([]App, error) {
+	var payloads []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&payloads); err != nil {
 		return nil, err
 	}
+
+	apps := make([]App, 0, len(payloads))
+
+	var decodeErr ErrAppsDecode
+	for i, payload := range payloads {
+		var app App
+		if err := c.decodeStrictJSON(bytes.NewReader(payload), &app); err != nil {
+			decodeErr.Errors = append(decodeErr.Errors, fmt.Errorf("app at index %d: %w", i, err))
+			continue
+		}
+		apps = append(apps, app)
+	}
+	if len(decodeErr.Errors) > 0 {
+		return apps, decodeErr
+	}
 	return apps, nil
 }
@@ -114,6 +114,58 @@ type AppDescription struct {
 	LogForwarders     []LogForwarderSummary      `json:"log_forwarders"`
 }
 
+// Resources lists the app's resources which would be destroyed along with it,
+// each formatted as "<kind>: <name>" (e.g. "function: test")
+func (desc AppDescription) Resources() []string {
+	var resources []string
+	add := func(kind, name string) {
+		resources = append(resources, kind+": "+name)
+	}
+
+	for _, dataSource := range desc.DataSources {
+		add("data source", dataSource.Name)
+	}
+	for _, service := range desc.ServiceDescs {
+		add("service", service.Name)
+		for _, webhook := range service.IncomingWebhooks {
+			add("webhook", service.Name+"/"+webhook.Name)
+		}
+	}
+	for _, endpoint := range desc.HTTPEndpoints {
+		add("http endpoint", endpoint.Name)
+	}
+	for _, function := range desc.Functions {
+		add("function", function.Name)
+	}
+	for _, trigger := range desc.EventSubscription {
+		add("trigger", trigger.Name)
+	}
+	for _, value := range desc.Values {
+		add("value", value)
+	}
+	for _, forwarder := range desc.LogForwarders {
+		add("log forwarder", forwarder.Name)
+	}
+	for _, resolver := range desc.GraphQL.CustomResolvers {
+		add("graphql custom resolver", resolver)
+	}
+	if desc.Sync.State != "" {
+		add("sync", desc.Sync.DataSource)
+	}
+	if desc.Hosting.Enabled {
+		add("hosting", desc.Hosting.URL)
+	}
+	return resources
+}
+
+func (c *client) PreDeleteCheck(groupID, appID string) ([]string, error) {
+	description, err := c.AppDescription(groupID, appID)
+	if err != nil {
+		return nil, err
+	}
+	return description.Resources(), nil
+}
+
 func (c *client) AppDescription(groupID, appID string) (AppDescription, error) {
 	res, resErr := c.do(
 		http.MethodGet,
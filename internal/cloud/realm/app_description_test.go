@@ -0,0 +1,46 @@
+package realm
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestAppDescriptionResources(t *testing.T) {
+	t.Run("should list the resources of an app", func(t *testing.T) {
+		desc := AppDescription{
+			DataSources: []DataSourceSummary{{Name: "mongodb-atlas", Type: "mongodb-atlas", DataSource: "Cluster0"}},
+			ServiceDescs: []ServiceSummary{{
+				Name:             "http",
+				Type:             "http",
+				IncomingWebhooks: []IncomingWebhookSummary{{Name: "hook"}},
+			}},
+			HTTPEndpoints:     []HTTPEndpointSummary{{Name: "endpoint"}},
+			Functions:         []FunctionSummary{{Name: "test"}},
+			EventSubscription: []EventSubscriptionSummary{{Name: "yell"}},
+			Values:            []string{"value"},
+			LogForwarders:     []LogForwarderSummary{{Name: "forwarder"}},
+			GraphQL:           GraphQLSummary{CustomResolvers: []string{"resolver"}},
+			Sync:              SyncSummary{State: "enabled", DataSource: "mongodb-atlas"},
+			Hosting:           HostingSummary{Enabled: true, URL: "https://eggcorn.mongodbstitch.com"},
+		}
+
+		assert.Equal(t, []string{
+			"data source: mongodb-atlas",
+			"service: http",
+			"webhook: http/hook",
+			"http endpoint: endpoint",
+			"function: test",
+			"trigger: yell",
+			"value: value",
+			"log forwarder: forwarder",
+			"graphql custom resolver: resolver",
+			"sync: mongodb-atlas",
+			"hosting: https://eggcorn.mongodbstitch.com",
+		}, desc.Resources())
+	})
+
+	t.Run("should list no resources for an empty app", func(t *testing.T) {
+		assert.Equal(t, 0, len(AppDescription{}.Resources()))
+	})
+}
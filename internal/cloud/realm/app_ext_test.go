@@ -41,6 +41,34 @@ func TestRealmApps(t *testing.T) {
 				assert.Equal(t, []realm.App{app}, apps)
 			})
 
+			t.Run("and find the app by group and client app id", func(t *testing.T) {
+				found, err := client.FindAppByClientAppID(groupID, app.ClientAppID)
+				assert.Nil(t, err)
+				assert.Equal(t, app, found)
+			})
+
+			t.Run("and list the app with the apps of its group", func(t *testing.T) {
+				appsByGroup, err := client.ListAllApps()
+				assert.Nil(t, err)
+
+				var found bool
+				for _, groupApp := range appsByGroup[groupID] {
+					if groupApp.ID == app.ID {
+						found = true
+					}
+				}
+				assert.True(t, found, "expected app to be listed with the apps of its group")
+			})
+
+			t.Run("and disable then enable the app", func(t *testing.T) {
+				assert.Equal(t, realm.ErrAppStateUnchanged{app.ClientAppID, false}, client.EnableApp(groupID, app.ID))
+
+				assert.Nil(t, client.DisableApp(groupID, app.ID))
+				assert.Equal(t, realm.ErrAppStateUnchanged{app.ClientAppID, true}, client.DisableApp(groupID, app.ID))
+
+				assert.Nil(t, client.EnableApp(groupID, app.ID))
+			})
+
 			// TODO(REALMC-9462): remove this once /apps has "template_id" in the payload
 			t.Run("and find the app by group and app id", func(t *testing.T) {
 				found, err := client.FindApp(app.GroupID, app.ID)
@@ -1,6 +1,9 @@
 package realm
 
 import (
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
@@ -29,3 +32,148 @@ func TestAppResolveProducts(t *testing.T) {
 		}
 	})
 }
+
+func TestFindAppByClientAppID(t *testing.T) {
+	apps := []App{
+		{ID: "app1", ClientAppID: "eggcorn-abcde"},
+		{ID: "app2", ClientAppID: "eggcorn-abcdef"},
+	}
+
+	t.Run("should find the app with the exact client app id", func(t *testing.T) {
+		app, err := findAppByClientAppID(apps, "eggcorn-abcdef")
+		assert.Nil(t, err)
+		assert.Equal(t, apps[1], app)
+	})
+
+	t.Run("should fail when no app has the client app id", func(t *testing.T) {
+		_, err := findAppByClientAppID(apps, "eggcorn")
+		assert.Equal(t, ErrAppNotFound{"eggcorn"}, err)
+	})
+}
+
+func TestClientDecodeApps(t *testing.T) {
+	t.Run("should decode all apps", func(t *testing.T) {
+		c := client{}
+
+		apps, err := c.decodeApps(strings.NewReader(`[{"_id":"app1","name":"one"},{"_id":"app2","name":"two"}]`))
+		assert.Nil(t, err)
+		assert.Equal(t, []App{{ID: "app1", Name: "one"}, {ID: "app2", Name: "two"}}, apps)
+	})
+
+	t.Run("should return the apps that decoded alongside the ones that failed", func(t *testing.T) {
+		c := client{}
+
+		apps, err := c.decodeApps(strings.NewReader(`[{"_id":"app1","name":"one"},{"_id":"app2","name":2},{"_id":"app3","last_used":"yesterday"}]`))
+		assert.Equal(t, []App{{ID: "app1", Name: "one"}}, apps)
+		assert.Equal(t, "failed to decode 2 app(s): "+
+			"app at index 1: json: cannot unmarshal number into Go struct field App.name of type string; "+
+			"app at index 2: json: cannot unmarshal string into Go struct field App.last_used of type int64", err.Error())
+	})
+
+	t.Run("should fail when the payload is not a list", func(t *testing.T) {
+		c := client{}
+
+		_, err := c.decodeApps(strings.NewReader(`{"_id":"app1"}`))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestClientAppsCache(t *testing.T) {
+	url := "/groups/groupID/apps"
+	apps := []App{{ID: "app1", ClientAppID: "app1-abcde"}, {ID: "app2", ClientAppID: "app2-fghij"}}
+
+	t.Run("should not have cached apps initially", func(t *testing.T) {
+		c := client{}
+
+		_, ok := c.cachedApps(url)
+		assert.False(t, ok, "expected apps to not be cached")
+	})
+
+	t.Run("should cache apps with an etag", func(t *testing.T) {
+		c := client{}
+		c.cacheApps(url, `"etag"`, apps)
+
+		cached, ok := c.cachedApps(url)
+		assert.True(t, ok, "expected apps to be cached")
+		assert.Equal(t, `"etag"`, cached.etag)
+		assert.Equal(t, apps, cached.apps)
+
+		t.Run("and evict them once listed without an etag", func(t *testing.T) {
+			c.cacheApps(url, "", apps)
+
+			_, ok := c.cachedApps(url)
+			assert.False(t, ok, "expected apps to no longer be cached")
+		})
+	})
+
+	t.Run("should not cache apps without an etag", func(t *testing.T) {
+		c := client{}
+		c.cacheApps(url, "", apps)
+
+		_, ok := c.cachedApps(url)
+		assert.False(t, ok, "expected apps to not be cached")
+	})
+}
+
+func TestValidateCreateApp(t *testing.T) {
+	t.Run("should pass with a valid name and metadata", func(t *testing.T) {
+		assert.Nil(t, ValidateCreateApp("eggcorn_app-1", AppMeta{}))
+		assert.Nil(t, ValidateCreateApp("eggcorn", AppMeta{
+			Location:        LocationIreland,
+			DeploymentModel: DeploymentModelLocal,
+			Environment:     EnvironmentProduction,
+		}))
+	})
+
+	for _, tc := range []struct {
+		description string
+		name        string
+		meta        AppMeta
+		expectedErr error
+	}{
+		{
+			description: "should fail without a name",
+			expectedErr: errors.New("app name is required"),
+		},
+		{
+			description: "should fail with a name containing disallowed characters",
+			name:        "egg corn!",
+			expectedErr: errors.New("invalid app name 'egg corn!': app names may only contain ASCII letters, numbers, underscores, and hyphens"),
+		},
+		{
+			description: "should fail with an invalid location",
+			name:        "eggcorn",
+			meta:        AppMeta{Location: "mars"},
+			expectedErr: errInvalidLocation,
+		},
+		{
+			description: "should fail with an invalid deployment model",
+			name:        "eggcorn",
+			meta:        AppMeta{DeploymentModel: "REGIONAL"},
+			expectedErr: errInvalidDeploymentModel,
+		},
+		{
+			description: "should fail with an invalid environment",
+			name:        "eggcorn",
+			meta:        AppMeta{Environment: "staging"},
+			expectedErr: errInvalidEnvironment,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateCreateApp(tc.name, tc.meta)
+			assert.Equal(t, tc.expectedErr.Error(), err.Error())
+		})
+	}
+
+	t.Run("should fail to create an app with invalid inputs before any request", func(t *testing.T) {
+		var requests []string
+		client := newTestClient(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			return testResponse(req, http.StatusCreated, `{"_id":"appID","client_app_id":"eggcorn-abcde","name":"eggcorn"}`), nil
+		})
+
+		_, err := client.CreateApp("groupID", "eggcorn", AppMeta{Location: "mars"})
+		assert.Equal(t, errInvalidLocation, err)
+		assert.Equal(t, 0, len(requests))
+	})
+}
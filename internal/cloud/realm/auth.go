@@ -1,16 +1,27 @@
 package realm
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/utils/api"
 )
 
 const (
-	authenticatePath = adminAPI + "/auth/providers/mongodb-cloud/login"
+	authenticatePath = adminAPI + "/auth/providers/" + authProviderCloud + "/login"
 	authProfilePath  = adminAPI + "/auth/profile"
 	authSessionPath  = adminAPI + "/auth/session"
+
+	// authProviderCloud is the provider users authenticate with, using their MongoDB Cloud API key
+	authProviderCloud = "mongodb-cloud"
+
+	// accessTokenExpiryLeeway is how long before its expiration an access token is considered expired
+	accessTokenExpiryLeeway = 10 * time.Second
 )
 
 // Session is the Realm session
@@ -19,6 +30,26 @@ type Session struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// set of auth event types
+const (
+	AuthEventLogin   AuthEventType = "login"
+	AuthEventRefresh AuthEventType = "refresh"
+)
+
+// AuthEventType is the type of an auth event
+type AuthEventType string
+
+// AuthEvent is a successful authentication of the user, which never holds
+// the user's credentials or tokens so it is safe to log
+type AuthEvent struct {
+	Type     AuthEventType
+	Provider string
+	Time     time.Time
+}
+
+// AuthEventHandler is called with every successful authentication of the user
+type AuthEventHandler func(event AuthEvent)
+
 type authenticateRequest struct {
 	PublicAPIKey  string `json:"username"`
 	PrivateAPIKey string `json:"apiKey"`
@@ -29,7 +60,7 @@ func (c *client) Authenticate(publicAPIKey, privateAPIKey string) (Session, erro
 		http.MethodPost,
 		authenticatePath,
 		authenticateRequest{publicAPIKey, privateAPIKey},
-		api.RequestOptions{NoAuth: true, PreventRefresh: true},
+		api.RequestOptions{NoAuth: true, PreventRefresh: true, Retry: true},
 	)
 	if resErr != nil {
 		return Session{}, resErr
@@ -43,9 +74,82 @@ func (c *client) Authenticate(publicAPIKey, privateAPIKey string) (Session, erro
 	if err := json.NewDecoder(res.Body).Decode(&session); err != nil {
 		return Session{}, err
 	}
+	c.notifyAuthEvent(AuthEventLogin)
 	return session, nil
 }
 
+func (c *client) SaveSession(session Session) error {
+	if c.store == nil {
+		return ErrInvalidSession{}
+	}
+	return c.store.Save(user.Session{session.AccessToken, session.RefreshToken})
+}
+
+func (c *client) ClearSession() error {
+	if c.store == nil {
+		return nil
+	}
+	return c.store.Clear()
+}
+
+// notifyAuthEvent calls the client's auth event handler, if any, with an event of the provided type
+func (c *client) notifyAuthEvent(eventType AuthEventType) {
+	if c.options.OnAuthenticate == nil {
+		return
+	}
+	c.options.OnAuthenticate(AuthEvent{Type: eventType, Provider: authProviderCloud, Time: c.now()})
+}
+
+func (c *client) RotateCredentials(publicAPIKey, privateAPIKey string) error {
+	if c.store == nil {
+		return ErrInvalidSession{}
+	}
+
+	// the previous session is cleared first so that the new credentials are never
+	// paired with it, subsequent requests are then made on behalf of the new credentials
+	if err := c.store.Clear(); err != nil {
+		return err
+	}
+
+	credentials := user.Credentials{publicAPIKey, privateAPIKey}
+
+	c.rotatedCredentialsMu.Lock()
+	c.rotatedCredentials = &credentials
+	c.rotatedCredentialsMu.Unlock()
+
+	if c.profile == nil {
+		return nil
+	}
+	c.profile.SetCredentials(credentials)
+	return c.profile.Save()
+}
+
+// authenticateWithRotatedCredentials logs in with the credentials the client rotated to,
+// if any, before the first request made on their behalf and saves the new session
+func (c *client) authenticateWithRotatedCredentials(options api.RequestOptions) error {
+	if options.NoAuth || options.RefreshAuth || c.store == nil {
+		return nil
+	}
+
+	c.rotatedCredentialsMu.Lock()
+	defer c.rotatedCredentialsMu.Unlock()
+
+	if c.rotatedCredentials == nil {
+		return nil
+	}
+
+	session, err := c.Authenticate(c.rotatedCredentials.PublicAPIKey, c.rotatedCredentials.PrivateAPIKey)
+	if err != nil {
+		return err
+	}
+	if err := c.store.Save(user.Session{session.AccessToken, session.RefreshToken}); err != nil {
+		return err
+	}
+
+	c.rotatedCredentials = nil
+	return nil
+}
+
 // AuthProfile is the user's profile
 type AuthProfile struct {
 	Roles []Role `json:"roles"`
@@ -67,7 +171,7 @@ func (c *client) AuthProfile() (AuthProfile, error) {
 	defer res.Body.Close()
 
 	var profile AuthProfile
-	if err := json.NewDecoder(res.Body).Decode(&profile); err != nil {
+	if err := c.decodeStrictJSON(res.Body, &profile); err != nil {
 		return AuthProfile{}, err
 	}
 	return profile, nil
@@ -78,11 +182,14 @@ func (c *client) getAuthToken(options api.RequestOptions) (string, error) {
 	requiresRefreshToken := options.RefreshAuth
 
 	if requiresAccessToken || requiresRefreshToken {
-		if c.profile == nil {
+		if c.store == nil {
 			return "", ErrInvalidSession{}
 		}
 
-		session := c.profile.Session()
+		session, err := c.store.Load()
+		if err != nil {
+			return "", err
+		}
 		if requiresRefreshToken {
 			if session.RefreshToken == "" {
 				return "", ErrInvalidSession{}
@@ -101,11 +208,78 @@ func (c *client) getAuthToken(options api.RequestOptions) (string, error) {
 	return "", nil
 }
 
+// refreshAuthIfExpired proactively refreshes the user's session
+// when its access token has expired according to the client's clock
+func (c *client) refreshAuthIfExpired(options api.RequestOptions) error {
+	if options.NoAuth || options.RefreshAuth || options.PreventRefresh || c.store == nil {
+		return nil
+	}
+
+	session, err := c.store.Load()
+	if err != nil {
+		return err
+	}
+
+	expiry, ok := parseAccessTokenExpiry(session.AccessToken)
+	if !ok || c.now().Before(expiry.Add(-accessTokenExpiryLeeway)) {
+		return nil
+	}
+
+	if err := c.refreshAuth(); err != nil {
+		if isInvalidSession(err) {
+			return c.invalidateSession()
+		}
+		return err
+	}
+	return nil
+}
+
+// isInvalidSession returns whether the error reports the user's session is no longer valid,
+// as opposed to any other failure (e.g. the server being unavailable)
+func isInvalidSession(err error) bool {
+	var serverErr ServerError
+	if errors.As(err, &serverErr) {
+		return serverErr.Code == errCodeInvalidSession
+	}
+	var invalidSessionErr ErrInvalidSession
+	return errors.As(err, &invalidSessionErr)
+}
+
+// invalidateSession clears the user's session after it failed to refresh
+func (c *client) invalidateSession() error {
+	if err := c.store.Clear(); err != nil {
+		return ErrInvalidSession{}
+	}
+	return ErrInvalidSession{}
+}
+
+// parseAccessTokenExpiry reads the "exp" claim of the provided JWT access token,
+// ok is false when the token or its claim cannot be parsed
+func parseAccessTokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Expiry, 0), true
+}
+
 func (c *client) refreshAuth() error {
 	res, resErr := c.do(
 		http.MethodPost,
 		authSessionPath,
-		api.RequestOptions{RefreshAuth: true},
+		api.RequestOptions{RefreshAuth: true, PreventRefresh: true},
 	)
 	if resErr != nil {
 		return resErr
@@ -120,11 +294,17 @@ func (c *client) refreshAuth() error {
 		return err
 	}
 
-	session := c.profile.Session()
+	session, err := c.store.Load()
+	if err != nil {
+		return err
+	}
 	session.AccessToken = s.AccessToken
-	c.profile.SetSession(session)
 
-	return c.profile.Save()
+	if err := c.store.Save(session); err != nil {
+		return err
+	}
+	c.notifyAuthEvent(AuthEventRefresh)
+	return nil
 }
 
 // AllGroupIDs returns all group ids associated with the user's profile
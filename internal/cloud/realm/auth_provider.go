@@ -1,5 +1,21 @@
 package realm
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	authProvidersPathPattern       = appPathPattern + "/auth_providers"
+	authProviderPathPattern        = authProvidersPathPattern + "/%s"
+	authProviderDisablePathPattern = authProviderPathPattern + "/disable"
+	authProviderEnablePathPattern  = authProviderPathPattern + "/enable"
+)
+
 // AuthProvider is a Realm application auth provider
 type AuthProvider struct {
 	ID                 string                 `json:"id,omitempty"`
@@ -19,3 +35,51 @@ type AuthMetdataField struct {
 	Name      string `json:"name"`
 	FieldName string `json:"field_name,omitempty"`
 }
+
+func (c *client) AuthProviders(groupID, appID string) ([]AuthProvider, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(authProvidersPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get auth providers", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var authProviders []AuthProvider
+	if err := json.NewDecoder(res.Body).Decode(&authProviders); err != nil {
+		return nil, err
+	}
+	return authProviders, nil
+}
+
+func (c *client) DisableAuthProvider(groupID, appID, providerID string) error {
+	return c.toggleAuthProvider(authProviderDisablePathPattern, "disable auth provider", groupID, appID, providerID)
+}
+
+func (c *client) EnableAuthProvider(groupID, appID, providerID string) error {
+	return c.toggleAuthProvider(authProviderEnablePathPattern, "enable auth provider", groupID, appID, providerID)
+}
+
+func (c *client) toggleAuthProvider(pathPattern, action, groupID, appID, providerID string) error {
+	res, resErr := c.do(
+		http.MethodPut,
+		fmt.Sprintf(pathPattern, groupID, appID, providerID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		var serverErr ServerError
+		if errors.As(resErr, &serverErr) && serverErr.Code == errCodeAuthProviderNotFound {
+			return ErrAuthProviderNotFound{providerID}
+		}
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{action, res.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRealmAuthProviders(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.AuthProviders(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "auth-providers-test")
+		defer teardown()
+
+		t.Run("should list the auth providers configured upon app initialization", func(t *testing.T) {
+			authProviders, err := client.AuthProviders(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(authProviders))
+
+			assert.Equal(t, realm.AuthProviderTypeAPIKey.String(), authProviders[0].Type)
+			assert.True(t, authProviders[0].Disabled, "expected api-key auth provider to be disabled")
+
+			t.Run("and enable the auth provider", func(t *testing.T) {
+				assert.Nil(t, client.EnableAuthProvider(groupID, app.ID, authProviders[0].ID))
+
+				found, err := client.AuthProviders(groupID, app.ID)
+				assert.Nil(t, err)
+				assert.False(t, found[0].Disabled, "expected api-key auth provider to be enabled")
+			})
+
+			t.Run("and disable the auth provider", func(t *testing.T) {
+				assert.Nil(t, client.DisableAuthProvider(groupID, app.ID, authProviders[0].ID))
+
+				found, err := client.AuthProviders(groupID, app.ID)
+				assert.Nil(t, err)
+				assert.True(t, found[0].Disabled, "expected api-key auth provider to be disabled")
+			})
+		})
+
+		t.Run("should return an error if the auth provider does not exist", func(t *testing.T) {
+			providerID := primitive.NewObjectID().Hex()
+
+			err := client.EnableAuthProvider(groupID, app.ID, providerID)
+			assert.Equal(t, realm.ErrAuthProviderNotFound{providerID}, err)
+		})
+	})
+}
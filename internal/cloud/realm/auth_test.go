@@ -31,6 +31,40 @@ func TestRealmAuthenticate(t *testing.T) {
 	})
 }
 
+func TestRealmRotateCredentials(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("Should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		err := client.RotateCredentials(u.CloudUsername(), u.CloudAPIKey())
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("With an auth client", func(t *testing.T) {
+		profile := mock.NewProfileWithSession(t, realm.Session{})
+		client := realm.NewAuthClient(profile.RealmBaseURL(), profile)
+
+		t.Run("Should fail the next request with invalid credentials", func(t *testing.T) {
+			assert.Nil(t, client.RotateCredentials("username", "apiKey"))
+			assert.Equal(t, user.Credentials{"username", "apiKey"}, profile.Credentials())
+			assert.Equal(t, user.Session{}, profile.Session())
+
+			_, err := client.AuthProfile()
+			assert.NotNil(t, err)
+		})
+
+		t.Run("Should replace the credentials and authenticate the next request with valid credentials", func(t *testing.T) {
+			assert.Nil(t, client.RotateCredentials(u.CloudUsername(), u.CloudAPIKey()))
+			assert.Equal(t, user.Credentials{u.CloudUsername(), u.CloudAPIKey()}, profile.Credentials())
+
+			_, err := client.AuthProfile()
+			assert.Nil(t, err)
+			assert.NotEqual(t, "", profile.Session().AccessToken, "access token must not be blank")
+		})
+	})
+}
+
 func TestRealmAuthProfile(t *testing.T) {
 	u.SkipUnlessRealmServerRunning(t)
 
@@ -3,9 +3,18 @@ package realm
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"sync"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cli/user"
 	"github.com/10gen/realm-cli/internal/utils/api"
@@ -23,22 +32,68 @@ const (
 type Client interface {
 	AuthProfile() (AuthProfile, error)
 	Authenticate(publicAPIKey, privateAPIKey string) (Session, error)
+	// SaveSession persists the user's session through the client's credential store
+	SaveSession(session Session) error
+	// ClearSession removes the user's session from the client's credential store
+	ClearSession() error
+	// RotateCredentials replaces the user's credentials, storing them in the client's profile if any,
+	// and clears the user's session so that the next request authenticates with the new credentials
+	RotateCredentials(publicAPIKey, privateAPIKey string) error
 
 	Export(groupID, appID string, req ExportRequest) (string, *zip.Reader, error)
+	ExportBytes(groupID, appID string, req ExportRequest) (string, []byte, error)
+	// ExportFiles returns the contents of each of the app's exported files keyed by their
+	// slash-separated path, along with the app's name, without writing anything to disk
+	ExportFiles(groupID, appID string, req ExportRequest) (map[string][]byte, string, error)
+	ExportEachFile(groupID, appID string, req ExportRequest, fn ExportFileFunc) error
+	ExportToTarGz(groupID, appID string, req ExportRequest, w io.Writer) error
+	ExportWithManifest(groupID, appID string, req ExportRequest) (ExportManifest, []byte, error)
 	ExportDependencies(groupID, appID string) (string, io.ReadCloser, error)
 	ExportDependenciesArchive(groupID, appID string) (string, io.ReadCloser, error)
 	Import(groupID, appID string, appData interface{}) error
+	ImportWithOptions(groupID, appID string, appData interface{}, opts ImportOptions) error
+	ImportMany(groupID string, appData map[string]interface{}, opts ImportOptions) error
+	// ImportAndVerify imports the app data and then diffs it against the app with the same
+	// options, failing with ErrImportNotConverged if any diffs remain; a clean diff proves
+	// a replace strategy import converged, whereas residual diffs may be expected with
+	// ImportStrategyMerge as it keeps the app's resources missing from the app data
+	ImportAndVerify(groupID, appID string, appData interface{}, opts ImportOptions) error
+	ImportYAML(groupID, appID string, data []byte, opts ImportOptions) error
 	ImportDependencies(groupID, appID, uploadPath string) error
+	// Diff returns the diff lines exactly as the server reports them, advisory warnings included;
+	// see DiffWithWarnings to have the warnings separated from the changes
 	Diff(groupID, appID string, appData interface{}) ([]string, error)
+	DiffDeployment(groupID, appID, deploymentID string, appData interface{}) ([]string, error)
+	DiffWithWarnings(groupID, appID string, appData interface{}) (DiffResult, error)
+	// DiffGrouped returns the changes importing the app data would make grouped by the category
+	// of the resource they are for (e.g. "functions"); see DiffResult.Grouped
+	DiffGrouped(groupID, appID string, appData interface{}) (map[string][]string, error)
+	// DestructiveChanges returns the paths of the resources (e.g. functions or rules) that importing
+	// the json encoded app data with the provided strategy would remove, additive changes aside
+	DestructiveChanges(groupID, appID string, appData []byte, strategy string) ([]string, error)
 	DiffDependencies(groupID, appID, uploadPath string) (DependenciesDiff, error)
+	Dependencies(groupID, appID string) ([]DependencyData, error)
 	DependenciesStatus(groupID, appID string) (DependenciesStatus, error)
+	// WaitForDependencies polls the app's dependencies status until their installation completes,
+	// calling onStatus (if provided) with each polled status so callers can report its progress
+	WaitForDependencies(groupID, appID string, timeout time.Duration, onStatus func(status DependenciesStatus)) error
 
 	CreateApp(groupID, name string, meta AppMeta) (App, error)
 	DeleteApp(groupID, appID string) error
+	PreDeleteCheck(groupID, appID string) ([]string, error)
+	DisableApp(groupID, appID string) error
+	EnableApp(groupID, appID string) error
 	// TODO(REALMC-9462): remove this once /apps has "template_id" in the payload
 	FindApp(groupID, appID string) (App, error)
 	FindApps(filter AppFilter) ([]App, error)
+	// ListAllApps returns the apps of every group associated with the user's profile keyed by group id,
+	// apps that fail to decode are reported with an ErrAppsDecode alongside the apps that did not
+	ListAllApps() (map[string][]App, error)
+	FindAppByClientAppID(groupID, clientAppID string) (App, error)
 	AppDescription(groupID, appID string) (AppDescription, error)
+	// FetchResource gets the raw json of the app's resource at the provided path relative to the app
+	// (e.g. "services/<id>/rules/<id>") as stored by the server, which is meant for debugging
+	FetchResource(groupID, appID, resourcePath string) (json.RawMessage, error)
 
 	CreateDraft(groupID, appID string) (AppDraft, error)
 	DeployDraft(groupID, appID, draftID string) (AppDeployment, error)
@@ -46,13 +101,26 @@ type Client interface {
 	DiscardDraft(groupID, appID, draftID string) error
 	Deployments(groupID, appID string) ([]AppDeployment, error)
 	Deployment(groupID, appID, deploymentID string) (AppDeployment, error)
+	WaitForDeploy(groupID, appID, deploymentID string, timeout time.Duration) error
 	Draft(groupID, appID string) (AppDraft, error)
+	DeployConfig(groupID, appID string) (DeployConfig, error)
+	UpdateDeployConfig(groupID, appID string, config DeployConfig) error
 
 	Secrets(groupID, appID string) ([]Secret, error)
 	CreateSecret(groupID, appID, name, value string) (Secret, error)
 	DeleteSecret(groupID, appID, secretID string) error
 	UpdateSecret(groupID, appID, secretID, name, value string) error
 
+	Values(groupID, appID string) ([]Value, error)
+	FindValue(groupID, appID, name string) (Value, error)
+
+	AuthProviders(groupID, appID string) ([]AuthProvider, error)
+	DisableAuthProvider(groupID, appID, providerID string) error
+	EnableAuthProvider(groupID, appID, providerID string) error
+
+	CustomUserData(groupID, appID string) (CustomUserDataConfig, error)
+	UpdateCustomUserData(groupID, appID string, config CustomUserDataConfig) error
+
 	CreateAPIKey(groupID, appID, apiKeyName string) (APIKey, error)
 	CreateUser(groupID, appID, email, password string) (User, error)
 	DeleteUser(groupID, appID, userID string) error
@@ -60,6 +128,8 @@ type Client interface {
 	EnableUser(groupID, appID, userID string) error
 	FindUsers(groupID, appID string, filter UserFilter) ([]User, error)
 	RevokeUserSessions(groupID, appID, userID string) error
+	ConfirmUser(groupID, appID, email string) error
+	ResetUserPassword(groupID, appID, email, password string) error
 
 	HostingAssets(groupID, appID string) ([]HostingAsset, error)
 	HostingAssetUpload(groupID, appID, rootDir string, asset HostingAsset) error
@@ -68,11 +138,39 @@ type Client interface {
 	HostingCacheInvalidate(groupID, appID, path string) error
 
 	Functions(groupID, appID string) ([]Function, error)
+	ExportFunction(groupID, appID, functionName string) (string, error)
+	UpdateFunction(groupID, appID, functionName, source string) error
 	AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error)
 
 	Logs(groupID, appID string, opts LogsOptions) (Logs, error)
-
+	LogsPage(groupID, appID string, opts LogsOptions, cursor LogsCursor) (Logs, LogsCursor, error)
+	// TriggerExecutions returns the recent executions of the trigger, most recent first; they are
+	// read from the app's trigger logs as the server keeps no separate execution records
+	TriggerExecutions(groupID, appID, triggerID string, opts TriggerExecutionsOptions) ([]TriggerExecution, error)
+	LogForwarders(groupID, appID string) ([]LogForwarder, error)
+	UpsertLogForwarder(groupID, appID string, forwarder LogForwarder) (LogForwarder, error)
+
+	Schemas(groupID, appID string) ([]Schema, error)
+	FindSchema(groupID, appID, serviceID, database, collection string) (Schema, error)
+	UpsertSchema(groupID, appID, serviceID, database, collection string, schema json.RawMessage) error
 	SchemaModels(groupID, appID, language string) ([]SchemaModel, error)
+	GraphQLSchema(groupID, appID string) (string, error)
+
+	TestDataSource(groupID, appID, serviceID string) error
+	DefaultRule(groupID, appID, serviceID string) (DefaultRule, error)
+	SetDefaultRule(groupID, appID, serviceID string, rule DefaultRule) (DefaultRule, error)
+	Webhooks(groupID, appID, serviceID string) ([]Webhook, error)
+	DisableWebhook(groupID, appID, serviceID, webhookID string) error
+	EnableWebhook(groupID, appID, serviceID, webhookID string) error
+	Endpoints(groupID, appID string) ([]Endpoint, error)
+	DisableEndpoint(groupID, appID, endpointID string) error
+	EnableEndpoint(groupID, appID, endpointID string) error
+
+	SyncConfig(groupID, appID string) (SyncConfig, error)
+	// PauseSync disables the app's sync until it is resumed, which is required
+	// before making breaking changes such as schema migrations
+	PauseSync(groupID, appID string) error
+	ResumeSync(groupID, appID string) error
 
 	AllTemplates() (Templates, error)
 	ClientTemplate(groupID, appID, templateID string) (*zip.Reader, bool, error)
@@ -83,22 +181,316 @@ type Client interface {
 	AllowedIPUpdate(groupID, appID, allowedIPID, newAddress, newComment string) error
 	AllowedIPDelete(groupID, appID, allowedIPID string) error
 
+	AllowedRequestOrigins(groupID, appID string) ([]string, error)
+	UpdateAllowedRequestOrigins(groupID, appID string, origins []string) error
+
+	ServerVersion() (string, error)
 	Status() error
+
+	// DoRequest makes an authenticated request to the path relative to the client's
+	// base url (e.g. "/api/admin/v3.0/groups/<groupID>/apps"), which allows calling
+	// endpoints the client does not support yet; decoding the response is left to the
+	// caller, who must close its body
+	DoRequest(method, path string, options api.RequestOptions) (*http.Response, error)
+
+	Close() error
+}
+
+// ClientOptions are options to configure a Realm client
+type ClientOptions struct {
+	// StrictDecoding fails to decode app and auth profile payloads containing
+	// fields unknown to the client, which is useful to detect API drift
+	StrictDecoding bool
+
+	// StrictErrorDecoding fails with the decode error when an error response's payload
+	// is not a valid server error, which is useful to catch content type or parsing
+	// mismatches; otherwise the payload is used as the error message
+	StrictErrorDecoding bool
+
+	// MaxExportSize is the maximum size in bytes of an export archive downloaded
+	// by ExportBytes, defaults to 256MiB when left unset; other exports are not capped
+	MaxExportSize int64
+
+	// Now is the clock used to check whether the user's access token has expired
+	// and should be refreshed before making a request, defaults to time.Now
+	Now func() time.Time
+
+	// TLSMinVersion is the minimum TLS version accepted on outbound connections
+	// (e.g. tls.VersionTLS12), defaults to the Go standard library's minimum
+	TLSMinVersion uint16
+
+	// TLSCipherSuites restricts the cipher suites negotiated on outbound connections
+	// using TLS 1.2 and below, defaults to the Go standard library's cipher suites
+	TLSCipherSuites []uint16
+
+	// InsecureSkipVerify skips verifying the server's certificate chain and host name,
+	// which is ONLY meant for local development against servers using self-signed
+	// certificates and must NEVER be used in production as it leaves connections
+	// open to man-in-the-middle attacks; callers are responsible for warning about it
+	InsecureSkipVerify bool
+
+	// ForceHTTP1 disables HTTP/2 so that every request is made over HTTP/1.1, which works
+	// around proxies misbehaving with HTTP/2; it takes precedence over RequireHTTP2
+	ForceHTTP1 bool
+
+	// RequireHTTP2 fails requests that cannot be made over HTTP/2 before they are sent,
+	// for gateways that require it; otherwise the protocol is negotiated as usual.
+	// Only HTTP/2 is offered when connecting, so connections to servers not negotiating it
+	// fail, and every request fails when the base url does not use https
+	RequireHTTP2 bool
+
+	// OperationLabel labels the requests made by the client with the operation they are made for
+	// (e.g. "nightly-deploy" or "pr-123"), which is sent as a header with every attempt of a request
+	// to correlate them with the server's logs; see WithOperationLabel to label a single operation
+	OperationLabel string
+
+	// OnAuthenticate is called each time the user logs in or their session is refreshed,
+	// which allows auditing authentications; its events never hold credentials or tokens
+	OnAuthenticate AuthEventHandler
+
+	// Retry configures how requests failing with a transient server error are retried,
+	// which currently only applies to authenticating
+	Retry RetryOptions
+
+	// RequestSigner is called right before each request attempt is sent with the
+	// request and its body, which allows the request to be signed (e.g. with an HMAC
+	// over its method, path and body set as a header) for gateways requiring it;
+	// the request is not sent if it fails
+	RequestSigner RequestSigner
+
+	// CredentialStore persists the user session, defaults to storing it
+	// in the CLI profile provided to the client
+	CredentialStore user.CredentialStore
+
+	// TransportMiddlewares wrap the transport used to send requests in order, so the
+	// last one is the outermost, which allows instrumenting every request sent by the
+	// client (e.g. with metrics or tracing) without it having to support each concern
+	TransportMiddlewares []TransportMiddleware
+
+	// TrustedRedirectHosts are the hosts, besides the base url's host, that redirects
+	// are followed to with the request's credentials (e.g. a gateway's regional hosts),
+	// either as "host" or "host:port"; redirects to any other host are refused
+	TrustedRedirectHosts []string
+
+	// Accept is the Accept header sent with every request, which allows pinning
+	// the Admin API version through content negotiation independently of the
+	// api path, request headers take precedence and no header is sent when empty
+	Accept string
 }
 
 // NewClient creates a new Realm client
 func NewClient(baseURL string) Client {
-	return &client{baseURL, nil}
+	return NewClientWithOptions(baseURL, nil, ClientOptions{})
 }
 
 // NewAuthClient creates a new Realm client capable of managing the user's session
 func NewAuthClient(baseURL string, profile *user.Profile) Client {
-	return &client{baseURL, profile}
+	return NewClientWithOptions(baseURL, profile, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new Realm client configured with the provided options,
+// the client is only capable of managing the user's session if a profile is provided
+func NewClientWithOptions(baseURL string, profile *user.Profile, options ClientOptions) Client {
+	store := options.CredentialStore
+	if store == nil && profile != nil {
+		store = user.NewProfileCredentialStore(profile)
+	}
+
+	httpClient, transport := newHTTPClient(options)
+	httpClient.CheckRedirect = newRedirectPolicy(baseURL, options.TrustedRedirectHosts)
+
+	var optionsErr error
+	if options.RequireHTTP2 && !options.ForceHTTP1 {
+		// HTTP/2 is only ever negotiated over TLS by the transport
+		if u, err := url.Parse(baseURL); err != nil || u.Scheme != "https" {
+			optionsErr = fmt.Errorf("%w: base url %s does not use https", errHTTP2Required, baseURL)
+		}
+	}
+
+	return &client{
+		baseURL:    baseURL,
+		profile:    profile,
+		store:      store,
+		options:    options,
+		optionsErr: optionsErr,
+		httpClient: httpClient,
+		transport:  transport,
+	}
 }
 
 type client struct {
-	baseURL string
-	profile *user.Profile
+	baseURL    string
+	profile    *user.Profile
+	store      user.CredentialStore
+	options    ClientOptions
+	httpClient *http.Client
+
+	// optionsErr is the error of the options the client is misconfigured with, if any,
+	// which fails every request before it is sent
+	optionsErr error
+
+	// transport is the base transport created for the client, if any, which is kept
+	// as the http client's transport may be wrapped by middlewares; it is nil when the
+	// client uses http.DefaultTransport, which is shared and never closed by the client
+	transport *http.Transport
+
+	// appsCache caches the apps listed per url alongside their ETag, so that
+	// listing them again is a conditional request answered without a payload
+	// when the apps have not changed
+	appsCache   map[string]appsCacheEntry
+	appsCacheMu sync.Mutex
+
+	// rotatedCredentials are the credentials the client rotated to,
+	// which are kept until the user is authenticated with them
+	rotatedCredentials   *user.Credentials
+	rotatedCredentialsMu sync.Mutex
+
+	// serverVersion caches the Realm server version for the client's lifetime
+	serverVersion   string
+	serverVersionMu sync.Mutex
+}
+
+// newHTTPClient creates the http client used to make requests, its transport is only
+// customized when TLS or HTTP version options or transport middlewares are provided;
+// the base transport is returned as well when one is created for the client
+func newHTTPClient(options ClientOptions) (*http.Client, *http.Transport) {
+	var base *http.Transport
+	if options.TLSMinVersion != 0 || len(options.TLSCipherSuites) > 0 || options.InsecureSkipVerify ||
+		options.ForceHTTP1 || options.RequireHTTP2 {
+		base = newTransport(options)
+	}
+
+	var transport http.RoundTripper
+	if base != nil {
+		transport = base
+	}
+
+	for _, middleware := range options.TransportMiddlewares {
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		transport = middleware(transport)
+	}
+
+	return &http.Client{Transport: transport}, base
+}
+
+func newTransport(options ClientOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         options.TLSMinVersion,
+		CipherSuites:       options.TLSCipherSuites,
+		InsecureSkipVerify: options.InsecureSkipVerify, //nolint: gosec
+	}
+
+	switch {
+	case options.ForceHTTP1:
+		// a non-nil empty map disables the transport's automatic HTTP/2 support
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	case options.RequireHTTP2:
+		transport.ForceAttemptHTTP2 = true
+		// the transport also offers HTTP/1.1 once it sets up HTTP/2, so connections
+		// are dialed with a copy of the config only offering HTTP/2
+		transport.TLSClientConfig.NextProtos = []string{http2Proto}
+		transport.DialTLSContext = dialTLSRequireHTTP2(transport.TLSClientConfig.Clone(), transport.TLSHandshakeTimeout)
+	}
+	return transport
+}
+
+const (
+	http2Proto = "h2"
+)
+
+// dialTLSRequireHTTP2 returns a dialer of TLS connections which fails
+// the connections to servers that do not negotiate HTTP/2
+func dialTLSRequireHTTP2(config *tls.Config, handshakeTimeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig := config.Clone()
+		if tlsConfig.ServerName == "" {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				host = addr
+			}
+			tlsConfig.ServerName = host
+		}
+
+		if handshakeTimeout > 0 {
+			conn.SetDeadline(time.Now().Add(handshakeTimeout)) //nolint: errcheck
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn.SetDeadline(time.Time{}) //nolint: errcheck
+
+		if proto := tlsConn.ConnectionState().NegotiatedProtocol; proto != http2Proto {
+			tlsConn.Close()
+			return nil, fmt.Errorf("%w: server at %s negotiated %q", errHTTP2Required, addr, proto)
+		}
+		return tlsConn, nil
+	}
+}
+
+func (c *client) DoRequest(method, path string, options api.RequestOptions) (*http.Response, error) {
+	return c.do(method, path, options)
+}
+
+func (c *client) Close() error {
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+
+	c.appsCacheMu.Lock()
+	c.appsCache = nil
+	c.appsCacheMu.Unlock()
+
+	c.serverVersionMu.Lock()
+	c.serverVersion = ""
+	c.serverVersionMu.Unlock()
+
+	return nil
+}
+
+func (c *client) now() time.Time {
+	if c.options.Now != nil {
+		return c.options.Now()
+	}
+	return time.Now()
+}
+
+// decodeStrictJSON decodes the json payload into the provided value,
+// failing on unknown fields when the client is configured to do so
+func (c *client) decodeStrictJSON(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	if c.options.StrictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+// includeExtraQuery adds the extra query parameters to the request query,
+// parameters already set on the query take precedence over the extra ones
+func includeExtraQuery(query, extra map[string]string) error {
+	for key, value := range extra {
+		if key == "" {
+			return errors.New("query parameter must have a name")
+		}
+		if _, ok := query[key]; ok {
+			continue
+		}
+		query[key] = value
+	}
+	return nil
 }
 
 func (c *client) doJSON(method, path string, payload interface{}, options api.RequestOptions) (*http.Response, error) {
@@ -114,60 +506,166 @@ func (c *client) doJSON(method, path string, payload interface{}, options api.Re
 }
 
 func (c *client) do(method, path string, options api.RequestOptions) (*http.Response, error) {
-	var bodyCopy bytes.Buffer
-	var tee io.Reader
+	var body []byte
 	if options.Body != nil {
-		tee = io.TeeReader(options.Body, &bodyCopy)
+		b, err := ioutil.ReadAll(options.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	retry := c.options.Retry.withDefaults()
+
+	var res *http.Response
+	for attempt := 1; ; attempt++ {
+		r, err := c.send(method, path, body, options)
+
+		var retryable bool
+		if options.Retry && retry.MaxAttempts > 1 {
+			ok, retryErr := retry.shouldRetry(r, err)
+			if retryErr != nil {
+				return nil, retryErr
+			}
+			retryable = ok
+		}
+		if retryable && attempt >= retry.MaxAttempts {
+			if exhaustedErr := c.retriesExhausted(attempt, r, err); exhaustedErr != nil {
+				return nil, exhaustedErr
+			}
+			retryable = false
+		}
+		if !retryable {
+			if err != nil {
+				return nil, err
+			}
+			res = r
+			break
+		}
+		if r != nil {
+			r.Body.Close()
+		}
+
+		if err := sleepContext(options.Context, retry.delay(attempt, retryJitter())); err != nil {
+			return nil, err
+		}
+	}
+
+	// not modified responses are only ever sent for conditional requests,
+	// which expect them as a successful outcome
+	if (res.StatusCode >= 200 && res.StatusCode <= 299) || res.StatusCode == http.StatusNotModified {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	parsedErr := parseResponseError(res, c.options.StrictErrorDecoding)
+	var serverErr ServerError
+	if !errors.As(parsedErr, &serverErr) || options.PreventRefresh || serverErr.Code != errCodeInvalidSession {
+		return nil, parsedErr
+	}
+
+	if refreshErr := c.refreshAuth(); refreshErr != nil {
+		return nil, c.invalidateSession()
+	}
+
+	options.PreventRefresh = true
+	if body != nil {
+		options.Body = bytes.NewReader(body)
+	}
+
+	return c.do(method, path, options)
+}
+
+// retriesExhausted returns the error for the final attempt of a request that would still be retried,
+// no error is returned when the attempt succeeded as its response is then used as is
+func (c *client) retriesExhausted(attempts int, res *http.Response, err error) error {
+	if err != nil {
+		return ErrRetriesExhausted{Attempts: attempts, Err: err}
+	}
+	if res.StatusCode >= 200 && res.StatusCode <= 299 {
+		return nil
+	}
+	defer res.Body.Close()
+
+	return ErrRetriesExhausted{
+		Attempts:   attempts,
+		StatusCode: res.StatusCode,
+		Err:        parseResponseError(res, c.options.StrictErrorDecoding),
 	}
+}
 
-	req, err := http.NewRequest(method, c.baseURL+path, tee)
+// send makes a single attempt of the request
+func (c *client) send(method, path string, body []byte, options api.RequestOptions) (*http.Response, error) {
+	if c.optionsErr != nil {
+		return nil, c.optionsErr
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, r)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.Context != nil {
+		req = req.WithContext(options.Context)
+	}
+
 	api.IncludeQuery(req, options.Query)
 
 	req.Header.Set(requestOriginHeader, cliHeaderValue)
+	req = c.labelOperation(req)
+
+	if c.options.Accept != "" {
+		req.Header.Set(api.HeaderAccept, c.options.Accept)
+	}
+
+	for key, value := range options.Headers {
+		req.Header.Set(key, value)
+	}
 
 	if options.ContentType != "" {
 		req.Header.Set(api.HeaderContentType, options.ContentType)
 	}
 
-	if token, err := c.getAuthToken(options); err != nil {
+	if err := c.authenticateWithRotatedCredentials(options); err != nil {
 		return nil, err
-	} else if token != "" {
-		req.Header.Set(api.HeaderAuthorization, "Bearer "+token)
 	}
 
-	client := &http.Client{}
+	if err := c.refreshAuthIfExpired(options); err != nil {
+		return nil, err
+	}
 
-	res, resErr := client.Do(req)
-	if resErr != nil {
-		return nil, resErr
+	if token, err := c.getAuthToken(options); err != nil {
+		return nil, err
+	} else if token != "" {
+		req.Header.Set(api.HeaderAuthorization, "Bearer "+token)
 	}
 
-	if res.StatusCode >= 200 && res.StatusCode <= 299 {
-		return res, nil
+	if c.options.RequestSigner != nil {
+		if err := c.options.RequestSigner(req, body); err != nil {
+			return nil, err
+		}
 	}
-	defer res.Body.Close()
 
-	parsedErr := parseResponseError(res)
-	if err, ok := parsedErr.(ServerError); !ok {
-		return nil, parsedErr
-	} else if options.PreventRefresh || err.Code != errCodeInvalidSession {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
 		return nil, err
 	}
-
-	if refreshErr := c.refreshAuth(); refreshErr != nil {
-		c.profile.ClearSession()
-		if err := c.profile.Save(); err != nil {
-			return nil, ErrInvalidSession{}
-		}
-		return nil, ErrInvalidSession{}
+	// connections made through a proxy are not dialed by the client,
+	// so the protocol they were made over is checked once more
+	if c.options.RequireHTTP2 && !c.options.ForceHTTP1 && res.ProtoMajor < 2 {
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: response was served over %s", errHTTP2Required, res.Proto)
 	}
+	return res, nil
+}
 
-	options.PreventRefresh = true
-	options.Body = &bodyCopy
+// RequestSigner signs the request about to be sent with the provided body
+type RequestSigner func(req *http.Request, body []byte) error
 
-	return c.do(method, path, options)
-}
+// TransportMiddleware wraps the transport used to send requests
+type TransportMiddleware func(next http.RoundTripper) http.RoundTripper
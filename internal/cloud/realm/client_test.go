@@ -0,0 +1,655 @@
+package realm
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientDecodeStrictJSON(t *testing.T) {
+	payload := `{"_id":"appID","name":"eggcorn","unknown_field":true}`
+
+	t.Run("should ignore unknown fields by default", func(t *testing.T) {
+		c := client{}
+
+		var app App
+		assert.Nil(t, c.decodeStrictJSON(strings.NewReader(payload), &app))
+		assert.Equal(t, App{ID: "appID", Name: "eggcorn"}, app)
+	})
+
+	t.Run("should fail on unknown fields with strict decoding", func(t *testing.T) {
+		c := client{options: ClientOptions{StrictDecoding: true}}
+
+		var app App
+		err := c.decodeStrictJSON(strings.NewReader(payload), &app)
+		assert.NotNil(t, err)
+		assert.Equal(t, `json: unknown field "unknown_field"`, err.Error())
+	})
+}
+
+func TestIncludeExtraQuery(t *testing.T) {
+	t.Run("should add the extra query parameters", func(t *testing.T) {
+		query := map[string]string{"version": "20210101"}
+
+		assert.Nil(t, includeExtraQuery(query, map[string]string{"new_feature": "a value&more"}))
+		assert.Equal(t, map[string]string{"version": "20210101", "new_feature": "a value&more"}, query)
+	})
+
+	t.Run("should not override the existing query parameters", func(t *testing.T) {
+		query := map[string]string{"version": "20210101"}
+
+		assert.Nil(t, includeExtraQuery(query, map[string]string{"version": "20200603"}))
+		assert.Equal(t, map[string]string{"version": "20210101"}, query)
+	})
+
+	t.Run("should fail with an unnamed query parameter", func(t *testing.T) {
+		err := includeExtraQuery(map[string]string{}, map[string]string{"": "value"})
+		assert.Equal(t, errors.New("query parameter must have a name"), err)
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("should use the default transport without tls options", func(t *testing.T) {
+		httpClient, _ := newHTTPClient(ClientOptions{})
+		assert.Nil(t, httpClient.Transport)
+	})
+
+	t.Run("should configure the transport with the tls options", func(t *testing.T) {
+		cipherSuites := []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}
+
+		httpClient, _ := newHTTPClient(ClientOptions{
+			TLSMinVersion:   tls.VersionTLS12,
+			TLSCipherSuites: cipherSuites,
+		})
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		assert.True(t, ok, "expected transport to be an *http.Transport")
+		assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+		assert.Equal(t, cipherSuites, transport.TLSClientConfig.CipherSuites)
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify, "expected tls verification to be enabled")
+	})
+
+	t.Run("should configure the transport to skip tls verification", func(t *testing.T) {
+		httpClient, _ := newHTTPClient(ClientOptions{InsecureSkipVerify: true})
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		assert.True(t, ok, "expected transport to be an *http.Transport")
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify, "expected tls verification to be skipped")
+	})
+
+	t.Run("should configure the transport to disable http/2", func(t *testing.T) {
+		httpClient, _ := newHTTPClient(ClientOptions{ForceHTTP1: true, RequireHTTP2: true})
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		assert.True(t, ok, "expected transport to be an *http.Transport")
+		assert.False(t, transport.ForceAttemptHTTP2, "expected http/2 to not be attempted")
+		assert.NotNil(t, transport.TLSNextProto)
+		assert.Equal(t, 0, len(transport.TLSNextProto))
+	})
+
+	t.Run("should configure the transport to attempt http/2", func(t *testing.T) {
+		httpClient, _ := newHTTPClient(ClientOptions{RequireHTTP2: true})
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		assert.True(t, ok, "expected transport to be an *http.Transport")
+		assert.True(t, transport.ForceAttemptHTTP2, "expected http/2 to be attempted")
+		assert.Nil(t, transport.TLSNextProto)
+		assert.Equal(t, []string{"h2"}, transport.TLSClientConfig.NextProtos)
+		assert.True(t, transport.DialTLSContext != nil, "expected tls connections to be dialed by the client")
+	})
+
+	t.Run("should wrap the transport with the middlewares in order", func(t *testing.T) {
+		var wrapped []string
+		middleware := func(name string) TransportMiddleware {
+			return func(next http.RoundTripper) http.RoundTripper {
+				wrapped = append(wrapped, name)
+				return testRoundTripper{name, next}
+			}
+		}
+
+		httpClient, base := newHTTPClient(ClientOptions{
+			TLSMinVersion:        tls.VersionTLS12,
+			TransportMiddlewares: []TransportMiddleware{middleware("metrics"), middleware("tracing")},
+		})
+		assert.Equal(t, []string{"metrics", "tracing"}, wrapped)
+
+		tracing, ok := httpClient.Transport.(testRoundTripper)
+		assert.True(t, ok, "expected transport to be wrapped")
+		assert.Equal(t, "tracing", tracing.name)
+
+		metrics, ok := tracing.next.(testRoundTripper)
+		assert.True(t, ok, "expected transport to be wrapped")
+		assert.Equal(t, "metrics", metrics.name)
+
+		transport, ok := metrics.next.(*http.Transport)
+		assert.True(t, ok, "expected transport to be an *http.Transport")
+		assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+		assert.True(t, base == transport, "expected the base transport to be returned")
+	})
+
+	t.Run("should wrap the default transport without tls options", func(t *testing.T) {
+		var next http.RoundTripper
+		httpClient, base := newHTTPClient(ClientOptions{
+			TransportMiddlewares: []TransportMiddleware{func(rt http.RoundTripper) http.RoundTripper {
+				next = rt
+				return rt
+			}},
+		})
+		assert.True(t, next == http.DefaultTransport, "expected the default transport to be wrapped")
+		assert.True(t, httpClient.Transport == http.DefaultTransport, "expected the transport to be set")
+		assert.True(t, base == nil, "expected no base transport to be returned")
+	})
+}
+
+type testRoundTripper struct {
+	name string
+	next http.RoundTripper
+}
+
+func (rt testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.next.RoundTrip(req)
+}
+
+func TestClientRefreshAuthIfExpired(t *testing.T) {
+	expiry := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	profile, err := user.NewProfile("refresh-auth-if-expired")
+	assert.Nil(t, err)
+	profile.SetSession(user.Session{AccessToken: testAccessToken(expiry)})
+	defer profile.ClearSession()
+
+	t.Run("should not refresh an access token that has yet to expire", func(t *testing.T) {
+		c := client{profile: profile, store: user.NewProfileCredentialStore(profile), options: ClientOptions{
+			Now: func() time.Time { return expiry.Add(-time.Minute) },
+		}}
+
+		assert.Nil(t, c.refreshAuthIfExpired(api.RequestOptions{}))
+	})
+
+	t.Run("should not refresh when the request prevents it", func(t *testing.T) {
+		c := client{profile: profile, store: user.NewProfileCredentialStore(profile), options: ClientOptions{
+			Now: func() time.Time { return expiry.Add(time.Minute) },
+		}}
+
+		for _, options := range []api.RequestOptions{
+			{NoAuth: true},
+			{RefreshAuth: true},
+			{PreventRefresh: true},
+		} {
+			assert.Nil(t, c.refreshAuthIfExpired(options))
+		}
+	})
+
+	newRefreshClient := func(store *testCredentialStore, statusCode int, body string, requests *[]string) Client {
+		return newTestClientWithOptions("http://localhost:8081", ClientOptions{
+			CredentialStore: store,
+			Now:             func() time.Time { return expiry.Add(time.Minute) },
+		}, func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.Path+" "+req.Header.Get(api.HeaderAuthorization))
+
+			if req.URL.Path == authSessionPath {
+				return testResponse(req, statusCode, body), nil
+			}
+			res := testResponse(req, http.StatusOK, `{"roles":[]}`)
+			res.Header.Set(api.HeaderContentType, api.MediaTypeJSON)
+			return res, nil
+		})
+	}
+
+	t.Run("should refresh an expired access token and save the new one", func(t *testing.T) {
+		store := &testCredentialStore{session: user.Session{AccessToken: testAccessToken(expiry), RefreshToken: "refresh-token"}}
+
+		var requests []string
+		client := newRefreshClient(store, http.StatusCreated, `{"access_token":"new-access-token"}`, &requests)
+
+		_, err := client.AuthProfile()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"POST " + authSessionPath + " Bearer refresh-token",
+			"GET " + authProfilePath + " Bearer new-access-token",
+		}, requests)
+		assert.Equal(t, user.Session{AccessToken: "new-access-token", RefreshToken: "refresh-token"}, store.session)
+	})
+
+	t.Run("should keep the session when the refresh fails for another reason than an invalid session", func(t *testing.T) {
+		session := user.Session{AccessToken: testAccessToken(expiry), RefreshToken: "refresh-token"}
+		store := &testCredentialStore{session: session}
+
+		var requests []string
+		client := newRefreshClient(store, http.StatusServiceUnavailable, `{"error":"service unavailable"}`, &requests)
+
+		_, err := client.AuthProfile()
+		assert.Equal(t, ServerError{Message: "service unavailable"}, err)
+		assert.Equal(t, session, store.session)
+	})
+
+	t.Run("should clear the session when the refresh reports an invalid session", func(t *testing.T) {
+		store := &testCredentialStore{session: user.Session{AccessToken: testAccessToken(expiry), RefreshToken: "refresh-token"}}
+
+		var requests []string
+		client := newRefreshClient(store, http.StatusUnauthorized, `{"error":"invalid session","error_code":"InvalidSession"}`, &requests)
+
+		_, err := client.AuthProfile()
+		assert.Equal(t, ErrInvalidSession{}, err)
+		assert.Equal(t, user.Session{}, store.session)
+	})
+}
+
+func TestClientCredentialStore(t *testing.T) {
+	t.Run("should default to storing the session in the profile", func(t *testing.T) {
+		profile, err := user.NewProfile("credential-store")
+		assert.Nil(t, err)
+		profile.SetSession(user.Session{AccessToken: "profile-token"})
+
+		c := NewClientWithOptions("", profile, ClientOptions{}).(*client)
+
+		token, err := c.getAuthToken(api.RequestOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "profile-token", token)
+	})
+
+	t.Run("should use the provided credential store", func(t *testing.T) {
+		store := &testCredentialStore{session: user.Session{AccessToken: "store-token"}}
+
+		c := NewClientWithOptions("", nil, ClientOptions{CredentialStore: store}).(*client)
+
+		token, err := c.getAuthToken(api.RequestOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "store-token", token)
+
+		assert.Equal(t, ErrInvalidSession{}, c.invalidateSession())
+		assert.Equal(t, user.Session{}, store.session)
+	})
+
+	t.Run("should fail with the credential store's error", func(t *testing.T) {
+		store := &testCredentialStore{err: errors.New("keychain is locked")}
+
+		c := NewClientWithOptions("", nil, ClientOptions{CredentialStore: store}).(*client)
+
+		_, err := c.getAuthToken(api.RequestOptions{})
+		assert.Equal(t, errors.New("keychain is locked"), err)
+	})
+}
+
+func TestClientRotateCredentials(t *testing.T) {
+	t.Run("should fail without a credential store", func(t *testing.T) {
+		c := NewClient("http://localhost:8081")
+		assert.Equal(t, ErrInvalidSession{}, c.RotateCredentials("public-key", "private-key"))
+	})
+
+	t.Run("should clear the session and authenticate with the new credentials on the next request", func(t *testing.T) {
+		store := &testCredentialStore{session: user.Session{AccessToken: "old-access-token", RefreshToken: "old-refresh-token"}}
+
+		var requests []string
+		c := newTestClientWithOptions("http://localhost:8081", ClientOptions{CredentialStore: store}, func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+req.Header.Get(api.HeaderAuthorization))
+
+			if req.URL.Path == authenticatePath {
+				data, err := ioutil.ReadAll(req.Body)
+				assert.Nil(t, err)
+				assert.Equal(t, `{"username":"public-key","apiKey":"private-key"}`, string(data))
+				return testResponse(req, http.StatusOK, `{"access_token":"new-access-token","refresh_token":"new-refresh-token"}`), nil
+			}
+			return testResponse(req, http.StatusOK, `{"roles":[]}`), nil
+		})
+
+		assert.Nil(t, c.RotateCredentials("public-key", "private-key"))
+		assert.Equal(t, user.Session{}, store.session)
+		assert.Equal(t, 0, len(requests))
+
+		for i := 0; i < 2; i++ {
+			_, err := c.AuthProfile()
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, user.Session{AccessToken: "new-access-token", RefreshToken: "new-refresh-token"}, store.session)
+		assert.Equal(t, []string{
+			"POST " + authenticatePath + " ",
+			"GET " + authProfilePath + " Bearer new-access-token",
+			"GET " + authProfilePath + " Bearer new-access-token",
+		}, requests)
+	})
+
+	t.Run("should keep the credentials when clearing the session fails", func(t *testing.T) {
+		profile, err := user.NewProfile("rotate-credentials")
+		assert.Nil(t, err)
+
+		store := &testCredentialStore{session: user.Session{AccessToken: "old-access-token"}, err: errors.New("something bad happened")}
+		c := NewClientWithOptions("http://localhost:8081", profile, ClientOptions{CredentialStore: store})
+
+		assert.Equal(t, errors.New("something bad happened"), c.RotateCredentials("public-key", "private-key"))
+		assert.Equal(t, user.Credentials{}, profile.Credentials())
+	})
+}
+
+type testCredentialStore struct {
+	session user.Session
+	err     error
+}
+
+func (s *testCredentialStore) Load() (user.Session, error) { return s.session, s.err }
+
+func (s *testCredentialStore) Save(session user.Session) error {
+	s.session = session
+	return s.err
+}
+
+func (s *testCredentialStore) Clear() error {
+	s.session = user.Session{}
+	return s.err
+}
+
+func TestClientRequestSigner(t *testing.T) {
+	t.Run("should sign the request before sending it", func(t *testing.T) {
+		var signed *http.Request
+		var signedBody []byte
+
+		c := NewClientWithOptions("http://localhost:8081", nil, ClientOptions{
+			RequestSigner: func(req *http.Request, body []byte) error {
+				signed = req
+				signedBody = body
+				return errors.New("failed to sign request")
+			},
+		}).(*client)
+
+		_, err := c.doJSON(http.MethodPost, "/path", map[string]string{"key": "value"}, api.RequestOptions{NoAuth: true})
+		assert.Equal(t, errors.New("failed to sign request"), err)
+
+		assert.Equal(t, http.MethodPost, signed.Method)
+		assert.Equal(t, "/path", signed.URL.Path)
+		assert.Equal(t, `{"key":"value"}`, string(signedBody))
+	})
+}
+
+func TestClientAccept(t *testing.T) {
+	for _, tc := range []struct {
+		description    string
+		accept         string
+		headers        map[string]string
+		expectedAccept string
+	}{
+		{
+			description: "should send no accept header by default",
+		},
+		{
+			description:    "should send the configured accept header",
+			accept:         "application/vnd.mongodb.admin.v3+json",
+			expectedAccept: "application/vnd.mongodb.admin.v3+json",
+		},
+		{
+			description:    "should let the request headers override the configured accept header",
+			accept:         "application/vnd.mongodb.admin.v3+json",
+			headers:        map[string]string{api.HeaderAccept: "application/zip"},
+			expectedAccept: "application/zip",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var sent []http.Header
+
+			c := newTestClientWithOptions("http://localhost:8081", ClientOptions{Accept: tc.accept}, func(req *http.Request) (*http.Response, error) {
+				sent = append(sent, req.Header)
+				return testResponse(req, http.StatusNoContent, ""), nil
+			})
+
+			res, err := c.do(http.MethodGet, "/path", api.RequestOptions{Headers: tc.headers, NoAuth: true})
+			assert.Nil(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+			assert.Equal(t, 1, len(sent))
+			assert.Equal(t, tc.expectedAccept, sent[0].Get(api.HeaderAccept))
+		})
+	}
+}
+
+func TestClientDoRequest(t *testing.T) {
+	t.Run("should make an authenticated request to the path and return its response", func(t *testing.T) {
+		var sent []*http.Request
+
+		c := newTestClient(func(req *http.Request) (*http.Response, error) {
+			sent = append(sent, req)
+
+			res := testResponse(req, http.StatusOK, `{"enabled":true}`)
+			res.Header.Set(api.HeaderContentType, api.MediaTypeJSON)
+			return res, nil
+		})
+
+		res, err := c.DoRequest(http.MethodGet, adminAPI+"/groups/groupID/new_feature", api.RequestOptions{
+			Query: map[string]string{"key": "value"},
+		})
+		assert.Nil(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, 1, len(sent))
+		assert.Equal(t, http.MethodGet, sent[0].Method)
+		assert.Equal(t, "http://localhost:8081/api/admin/v3.0/groups/groupID/new_feature?key=value", sent[0].URL.String())
+		assert.Equal(t, "Bearer access-token", sent[0].Header.Get(api.HeaderAuthorization))
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, api.MediaTypeJSON, res.Header.Get(api.HeaderContentType))
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"enabled":true}`, string(body))
+	})
+}
+
+func TestClientRequireHTTP2(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		protoMajor  int
+		expectedErr error
+	}{
+		{
+			description: "should fail a response served over http/1.1",
+			protoMajor:  1,
+			expectedErr: fmt.Errorf("%w: response was served over HTTP/1.1", errHTTP2Required),
+		},
+		{
+			description: "should accept a response served over http/2",
+			protoMajor:  2,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			protoMajor := tc.protoMajor
+
+			c := newTestClientWithOptions("https://localhost:8081", ClientOptions{RequireHTTP2: true}, func(req *http.Request) (*http.Response, error) {
+				res := testResponse(req, http.StatusNoContent, "")
+				res.Proto = fmt.Sprintf("HTTP/%d.%d", protoMajor, 2-protoMajor)
+				res.ProtoMajor = protoMajor
+				return res, nil
+			})
+
+			_, err := c.do(http.MethodGet, "/path", api.RequestOptions{NoAuth: true})
+			if tc.expectedErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.True(t, errors.Is(err, errHTTP2Required), "expected error to be an http/2 required error")
+			}
+		})
+	}
+}
+
+func TestClientRequireHTTP2Connection(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		enableHTTP2 bool
+	}{
+		{description: "should fail to connect to a server not negotiating http/2 before sending the request"},
+		{description: "should send the request to a server negotiating http/2", enableHTTP2: true},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var requests int
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			server.EnableHTTP2 = tc.enableHTTP2
+			server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+			server.StartTLS()
+			defer server.Close()
+
+			c := NewClientWithOptions(server.URL, nil, ClientOptions{RequireHTTP2: true, InsecureSkipVerify: true})
+			defer c.Close()
+
+			res, err := c.DoRequest(http.MethodPost, "/path", api.RequestOptions{NoAuth: true})
+			if tc.enableHTTP2 {
+				assert.Nil(t, err)
+				defer res.Body.Close()
+				assert.Equal(t, 2, res.ProtoMajor)
+				assert.Equal(t, 1, requests)
+			} else {
+				// depending on the server, either the handshake fails or it does not negotiate a protocol
+				assert.NotNil(t, err)
+				assert.Equal(t, 0, requests)
+			}
+		})
+	}
+
+	t.Run("should fail every request without sending it when the base url does not use https", func(t *testing.T) {
+		var requests int
+		c := newTestClientWithOptions("http://localhost:8081", ClientOptions{RequireHTTP2: true}, func(req *http.Request) (*http.Response, error) {
+			requests++
+			return testResponse(req, http.StatusNoContent, ""), nil
+		})
+
+		_, err := c.DoRequest(http.MethodPost, "/path", api.RequestOptions{NoAuth: true})
+		assert.Equal(t, fmt.Errorf("%w: base url http://localhost:8081 does not use https", errHTTP2Required).Error(), err.Error())
+		assert.Equal(t, 0, requests)
+	})
+}
+
+type testRoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f testRoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// newTestClient creates a client authenticated with an access token
+// whose requests are answered by the handler instead of being sent
+func newTestClient(handler testRoundTripperFunc) *client {
+	return newTestClientWithOptions("http://localhost:8081", ClientOptions{}, handler)
+}
+
+// newTestClientWithOptions creates a client like newTestClient but with the base url and options,
+// it is only authenticated with an access token when the options have no credential store
+func newTestClientWithOptions(baseURL string, options ClientOptions, handler testRoundTripperFunc) *client {
+	if options.CredentialStore == nil {
+		options.CredentialStore = &testCredentialStore{session: user.Session{AccessToken: "access-token"}}
+	}
+	options.TransportMiddlewares = append(options.TransportMiddlewares, func(next http.RoundTripper) http.RoundTripper {
+		return handler
+	})
+	return NewClientWithOptions(baseURL, nil, options).(*client)
+}
+
+// testResponse returns a response to the request with the status code and body
+func testResponse(req *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	t.Run("should release the cached state and be safe to call more than once", func(t *testing.T) {
+		c := NewClient("http://localhost:8081").(*client)
+		c.cacheApps("/apps", "etag", []App{{ID: "appID"}})
+		c.serverVersion = "v1.0.0"
+
+		assert.Nil(t, c.Close())
+		assert.Nil(t, c.Close())
+		assert.True(t, c.transport == nil, "expected the client to not own a transport")
+
+		_, ok := c.cachedApps("/apps")
+		assert.False(t, ok, "expected apps cache to be cleared")
+		assert.Equal(t, "", c.serverVersion)
+	})
+}
+
+func TestParseAccessTokenExpiry(t *testing.T) {
+	expiry := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		description    string
+		token          string
+		expectedExpiry time.Time
+		expectedOK     bool
+	}{
+		{
+			description:    "should parse the expiry of a valid token",
+			token:          testAccessToken(expiry),
+			expectedExpiry: expiry,
+			expectedOK:     true,
+		},
+		{
+			description: "should fail to parse a token that is not a jwt",
+			token:       "access-token",
+		},
+		{
+			description: "should fail to parse a token with an invalid payload",
+			token:       "header.!!!.signature",
+		},
+		{
+			description: "should fail to parse a token without an expiry",
+			token:       "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user"}`)) + ".signature",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			actualExpiry, ok := parseAccessTokenExpiry(tc.token)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.True(t, tc.expectedExpiry.Equal(actualExpiry), "expected expiry to equal %s but got %s", tc.expectedExpiry, actualExpiry)
+		})
+	}
+}
+
+func TestClientOnAuthenticate(t *testing.T) {
+	now := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	var events []AuthEvent
+
+	c := newTestClientWithOptions("http://localhost:8081", ClientOptions{
+		CredentialStore: &testCredentialStore{session: user.Session{RefreshToken: "refresh-token"}},
+		Now:             func() time.Time { return now },
+		OnAuthenticate:  func(event AuthEvent) { events = append(events, event) },
+	}, func(req *http.Request) (*http.Response, error) {
+		const session = `{"access_token":"access-token","refresh_token":"refresh-token"}`
+		switch req.URL.Path {
+		case authSessionPath:
+			return testResponse(req, http.StatusCreated, session), nil
+		case authenticatePath:
+			return testResponse(req, http.StatusOK, session), nil
+		}
+		return testResponse(req, http.StatusUnauthorized, `{"error_code":"InvalidSession"}`), nil
+	})
+
+	t.Run("should notify of a login", func(t *testing.T) {
+		_, err := c.Authenticate("public-key", "private-key")
+		assert.Nil(t, err)
+		assert.Equal(t, []AuthEvent{{Type: AuthEventLogin, Provider: "mongodb-cloud", Time: now}}, events)
+	})
+
+	t.Run("should notify of a session refresh", func(t *testing.T) {
+		events = nil
+
+		assert.Nil(t, c.refreshAuth())
+		assert.Equal(t, []AuthEvent{{Type: AuthEventRefresh, Provider: "mongodb-cloud", Time: now}}, events)
+	})
+}
+
+func testAccessToken(expiry time.Time) string {
+	payload := fmt.Sprintf(`{"sub":"user","exp":%d}`, expiry.Unix())
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
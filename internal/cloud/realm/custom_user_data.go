@@ -0,0 +1,59 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	customUserDataPathPattern = appPathPattern + "/custom_user_data"
+)
+
+// CustomUserDataConfig is the custom user data config of a Realm app
+type CustomUserDataConfig struct {
+	Enabled        bool   `json:"enabled"`
+	MongoServiceID string `json:"mongo_service_id,omitempty"`
+	DatabaseName   string `json:"database_name,omitempty"`
+	CollectionName string `json:"collection_name,omitempty"`
+	UserIDField    string `json:"user_id_field,omitempty"`
+}
+
+func (c *client) CustomUserData(groupID, appID string) (CustomUserDataConfig, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(customUserDataPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return CustomUserDataConfig{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return CustomUserDataConfig{}, api.ErrUnexpectedStatusCode{"get custom user data", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var config CustomUserDataConfig
+	if err := json.NewDecoder(res.Body).Decode(&config); err != nil {
+		return CustomUserDataConfig{}, err
+	}
+	return config, nil
+}
+
+func (c *client) UpdateCustomUserData(groupID, appID string, config CustomUserDataConfig) error {
+	res, resErr := c.doJSON(
+		http.MethodPatch,
+		fmt.Sprintf(customUserDataPathPattern, groupID, appID),
+		config,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update custom user data", res.StatusCode}
+	}
+	return nil
+}
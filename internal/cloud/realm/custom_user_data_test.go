@@ -0,0 +1,54 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmCustomUserData(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.CustomUserData(u.CloudGroupID(), "test-app-1234")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "custom-user-data-test")
+		defer teardown()
+
+		t.Run("should get the default custom user data config", func(t *testing.T) {
+			config, err := client.CustomUserData(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, realm.CustomUserDataConfig{}, config)
+		})
+
+		t.Run("should fail to enable the custom user data config without a data source", func(t *testing.T) {
+			err := client.UpdateCustomUserData(groupID, app.ID, realm.CustomUserDataConfig{
+				Enabled:        true,
+				MongoServiceID: "missing",
+				DatabaseName:   "db",
+				CollectionName: "coll",
+				UserIDField:    "uid",
+			})
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should update the custom user data config", func(t *testing.T) {
+			assert.Nil(t, client.UpdateCustomUserData(groupID, app.ID, realm.CustomUserDataConfig{}))
+
+			config, err := client.CustomUserData(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, realm.CustomUserDataConfig{}, config)
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	defaultRulePathPattern       = servicePathPattern + "/default_rule"
+	defaultRuleUpdatePathPattern = defaultRulePathPattern + "/%s"
+)
+
+// DefaultRule is the rule of a Realm app data source applied
+// to each of its collections that has no rule of its own
+type DefaultRule struct {
+	ID      string            `json:"_id,omitempty"`
+	Roles   []RuleRole        `json:"roles"`
+	Filters []json.RawMessage `json:"filters,omitempty"`
+}
+
+// RuleRole is a role of a Realm app rule, its permissions are either
+// a boolean or an expression evaluated for each document
+type RuleRole struct {
+	Name      string          `json:"name"`
+	ApplyWhen json.RawMessage `json:"apply_when,omitempty"`
+	Read      json.RawMessage `json:"read,omitempty"`
+	Write     json.RawMessage `json:"write,omitempty"`
+	Insert    json.RawMessage `json:"insert,omitempty"`
+	Delete    json.RawMessage `json:"delete,omitempty"`
+	Search    json.RawMessage `json:"search,omitempty"`
+}
+
+func (c *client) DefaultRule(groupID, appID, serviceID string) (DefaultRule, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(defaultRulePathPattern, groupID, appID, serviceID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return DefaultRule{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return DefaultRule{}, api.ErrUnexpectedStatusCode{"get default rule", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var rule DefaultRule
+	if err := json.NewDecoder(res.Body).Decode(&rule); err != nil {
+		return DefaultRule{}, err
+	}
+	return rule, nil
+}
+
+func (c *client) SetDefaultRule(groupID, appID, serviceID string, rule DefaultRule) (DefaultRule, error) {
+	if rule.ID != "" {
+		res, resErr := c.doJSON(
+			http.MethodPut,
+			fmt.Sprintf(defaultRuleUpdatePathPattern, groupID, appID, serviceID, rule.ID),
+			rule,
+			api.RequestOptions{},
+		)
+		if resErr != nil {
+			return DefaultRule{}, resErr
+		}
+		if res.StatusCode != http.StatusNoContent {
+			return DefaultRule{}, api.ErrUnexpectedStatusCode{"update default rule", res.StatusCode}
+		}
+		return rule, nil
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(defaultRulePathPattern, groupID, appID, serviceID),
+		rule,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return DefaultRule{}, resErr
+	}
+	if res.StatusCode != http.StatusCreated {
+		return DefaultRule{}, api.ErrUnexpectedStatusCode{"create default rule", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var created DefaultRule
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return DefaultRule{}, err
+	}
+	return created, nil
+}
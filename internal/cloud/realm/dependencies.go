@@ -3,13 +3,12 @@ package realm
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"mime"
 	"mime/multipart"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
 )
@@ -37,6 +36,31 @@ const (
 	DependenciesStateFailed     = "failed"
 )
 
+type dependenciesResponse struct {
+	Dependencies []DependencyData `json:"dependencies_list"`
+}
+
+func (c *client) Dependencies(groupID, appID string) ([]DependencyData, error) {
+	res, err := c.do(
+		http.MethodGet,
+		fmt.Sprintf(dependenciesPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get dependencies", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var deps dependenciesResponse
+	if err := json.NewDecoder(res.Body).Decode(&deps); err != nil {
+		return nil, err
+	}
+	return deps.Dependencies, nil
+}
+
 func (c *client) DependenciesStatus(groupID, appID string) (DependenciesStatus, error) {
 	res, err := c.do(
 		http.MethodGet,
@@ -57,6 +81,28 @@ func (c *client) DependenciesStatus(groupID, appID string) (DependenciesStatus,
 	return status, nil
 }
 
+func (c *client) WaitForDependencies(groupID, appID string, timeout time.Duration, onStatus func(status DependenciesStatus)) error {
+	var status DependenciesStatus
+	if err := pollUntil(pollOptions{defaultPollInterval, defaultPollJitter, timeout}, func() (bool, error) {
+		s, err := c.DependenciesStatus(groupID, appID)
+		if err != nil {
+			return false, err
+		}
+		status = s
+		if onStatus != nil {
+			onStatus(status)
+		}
+		return status.State != DependenciesStateCreated, nil
+	}); err != nil {
+		return err
+	}
+
+	if status.State == DependenciesStateFailed {
+		return ErrDependenciesFailed{status.Message}
+	}
+	return nil
+}
+
 func (c *client) ImportDependencies(groupID, appID, uploadPath string) error {
 	file, fileErr := os.Open(uploadPath)
 	if fileErr != nil {
@@ -111,14 +157,9 @@ func (c *client) ExportDependencies(groupID, appID string) (string, io.ReadClose
 		return "", nil, api.ErrUnexpectedStatusCode{"export dependencies", res.StatusCode}
 	}
 
-	_, mediaParams, mediaErr := mime.ParseMediaType(res.Header.Get(api.HeaderContentDisposition))
-	if mediaErr != nil {
-		return "", nil, mediaErr
-	}
-
-	filename := mediaParams[mediaParamFilename]
-	if filename == "" {
-		return "", nil, errors.New("export response is missing filename")
+	filename, filenameErr := exportFilename(res.Header.Get(api.HeaderContentDisposition))
+	if filenameErr != nil {
+		return "", nil, filenameErr
 	}
 
 	return filename, res.Body, nil
@@ -133,14 +174,9 @@ func (c *client) ExportDependenciesArchive(groupID, appID string) (string, io.Re
 		return "", nil, api.ErrUnexpectedStatusCode{"export dependencies archive", res.StatusCode}
 	}
 
-	_, mediaParams, mediaErr := mime.ParseMediaType(res.Header.Get(api.HeaderContentDisposition))
-	if mediaErr != nil {
-		return "", nil, mediaErr
-	}
-
-	filename := mediaParams[mediaParamFilename]
-	if filename == "" {
-		return "", nil, errors.New("export response is missing filename")
+	filename, filenameErr := exportFilename(res.Header.Get(api.HeaderContentDisposition))
+	if filenameErr != nil {
+		return "", nil, filenameErr
 	}
 
 	return filename, res.Body, nil
@@ -60,6 +60,12 @@ func TestRealmDependencies(t *testing.T) {
 			t.Run("and wait for those dependencies to be deployed to the app", func(t *testing.T) {
 				waitForDepDeployment(client, groupID, app.ID, t)
 			})
+
+			t.Run("and list the installed dependencies", func(t *testing.T) {
+				deps, err := client.Dependencies(groupID, app.ID)
+				assert.Nil(t, err)
+				assert.NotEqualf(t, 0, len(deps), "expected dependencies to be installed")
+			})
 		})
 
 		t.Run("should successfully export a zip node_modules archive", func(t *testing.T) {
@@ -0,0 +1,52 @@
+package realm
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		finalState  string
+		expectedErr error
+	}{
+		{
+			description: "should report each polled status until the installation succeeds",
+			finalState:  DependenciesStateSuccessful,
+		},
+		{
+			description: "should report each polled status until the installation fails",
+			finalState:  DependenciesStateFailed,
+			expectedErr: ErrDependenciesFailed{"failed to install"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			statuses := []DependenciesStatus{
+				{State: DependenciesStateCreated, Message: "installing modules"},
+				{State: tc.finalState, Message: "failed to install"},
+			}
+
+			var polls int
+			c := newTestClient(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/api/admin/v3.0/groups/groupID/apps/appID/dependencies/status", req.URL.Path)
+
+				data, err := json.Marshal(statuses[polls])
+				assert.Nil(t, err)
+				polls++
+
+				return testResponse(req, http.StatusOK, string(data)), nil
+			})
+
+			var reported []DependenciesStatus
+			err := c.WaitForDependencies("groupID", "appID", 0, func(status DependenciesStatus) {
+				reported = append(reported, status)
+			})
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, statuses, reported)
+		})
+	}
+}
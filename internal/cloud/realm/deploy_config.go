@@ -0,0 +1,71 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	deployConfigPathPattern = appPathPattern + "/deploy/config"
+)
+
+// set of supported automatic deployment providers
+const (
+	DeployProviderGitHub = "github"
+)
+
+// DeployConfig is the deployment configuration of a Realm app
+type DeployConfig struct {
+	AutomaticDeployment AutomaticDeploymentConfig `json:"automatic_deployment"`
+	UIDraftsDisabled    bool                      `json:"ui_drafts_disabled"`
+}
+
+// AutomaticDeploymentConfig is the configuration for automatically deploying
+// a Realm app whenever changes are pushed to the linked git repository
+type AutomaticDeploymentConfig struct {
+	Enabled    bool   `json:"enabled"`
+	Provider   string `json:"provider,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	Directory  string `json:"directory,omitempty"`
+}
+
+func (c *client) DeployConfig(groupID, appID string) (DeployConfig, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(deployConfigPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return DeployConfig{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return DeployConfig{}, api.ErrUnexpectedStatusCode{"get deploy config", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var config DeployConfig
+	if err := json.NewDecoder(res.Body).Decode(&config); err != nil {
+		return DeployConfig{}, err
+	}
+	return config, nil
+}
+
+func (c *client) UpdateDeployConfig(groupID, appID string, config DeployConfig) error {
+	res, resErr := c.doJSON(
+		http.MethodPatch,
+		fmt.Sprintf(deployConfigPathPattern, groupID, appID),
+		config,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update deploy config", res.StatusCode}
+	}
+	return nil
+}
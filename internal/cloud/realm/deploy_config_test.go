@@ -0,0 +1,43 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmDeployConfig(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.DeployConfig(u.CloudGroupID(), "test-app-1234")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "deploy-config-test")
+		defer teardown()
+
+		t.Run("should have automatic deployment disabled upon app initialization", func(t *testing.T) {
+			config, err := client.DeployConfig(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.False(t, config.AutomaticDeployment.Enabled, "expected automatic deployment to be disabled")
+		})
+
+		t.Run("should update the deploy config", func(t *testing.T) {
+			assert.Nil(t, client.UpdateDeployConfig(groupID, app.ID, realm.DeployConfig{UIDraftsDisabled: true}))
+
+			config, err := client.DeployConfig(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.True(t, config.UIDraftsDisabled, "expected ui drafts to be disabled")
+		})
+	})
+}
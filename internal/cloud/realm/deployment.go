@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
 )
@@ -72,3 +73,22 @@ func (c *client) Deployment(groupID, appID, deploymentID string) (AppDeployment,
 	}
 	return deployment, nil
 }
+
+func (c *client) WaitForDeploy(groupID, appID, deploymentID string, timeout time.Duration) error {
+	var deployment AppDeployment
+	if err := pollUntil(pollOptions{defaultPollInterval, defaultPollJitter, timeout}, func() (bool, error) {
+		d, err := c.Deployment(groupID, appID, deploymentID)
+		if err != nil {
+			return false, err
+		}
+		deployment = d
+		return deployment.Status != DeploymentStatusCreated && deployment.Status != DeploymentStatusPending, nil
+	}); err != nil {
+		return err
+	}
+
+	if deployment.Status == DeploymentStatusFailed {
+		return ErrDeploymentFailed{deploymentID, deployment.StatusErrorMessage}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
 	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 
@@ -52,6 +53,18 @@ func TestRealmDeployments(t *testing.T) {
 				assert.Nil(t, err)
 				assert.Equal(t, []realm.AppDeployment{found}, all)
 			})
+
+			t.Run("and be able to diff against the deployment", func(t *testing.T) {
+				diffs, err := client.DiffDeployment(groupID, app.ID, deployment.ID, local.AppDataV2{local.AppStructureV2{
+					ConfigVersion:   realm.AppConfigVersion20210101,
+					ID:              app.ClientAppID,
+					Name:            app.Name,
+					Location:        app.Location,
+					DeploymentModel: app.DeploymentModel,
+				}})
+				assert.Nil(t, err)
+				assert.Equal(t, 0, len(diffs))
+			})
 		})
 	})
 }
@@ -2,10 +2,152 @@ package realm
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/10gen/realm-cli/internal/terminal"
 )
 
+// set of prefixes marking the advisory lines of an app diff
+var diffWarningPrefixes = []string{"warning:", "note:"}
+
+// set of prefixes marking the old and new versions of a resource in an app diff
+const (
+	diffOldPathPrefix = "--- "
+	diffNewPathPrefix = "+++ "
+	diffHunkPrefix    = "@@"
+)
+
+// set of categories for the changes of an app diff that are not for a resource directory
+const (
+	diffCategoryApp   = "app"
+	diffCategoryOther = "other"
+)
+
+// DiffResult is the result of diffing app data against a Realm app,
+// with the server's advisory notes separated from the actual changes
+type DiffResult struct {
+	Changes  []string
+	Warnings []string
+}
+
+// newDiffResult splits the diff lines into changes and warnings
+func newDiffResult(diffs []string) DiffResult {
+	var result DiffResult
+	for _, diff := range diffs {
+		if isDiffWarning(diff) {
+			result.Warnings = append(result.Warnings, diff)
+		} else {
+			result.Changes = append(result.Changes, diff)
+		}
+	}
+	return result
+}
+
+func isDiffWarning(diff string) bool {
+	line := strings.ToLower(strings.TrimSpace(diff))
+	for _, prefix := range diffWarningPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasChanges returns whether the diff has any changes, warnings aside
+func (d DiffResult) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// Strings returns the diff as a flat list of strings,
+// with the changes followed by the warnings
+func (d DiffResult) Strings() []string {
+	diffs := make([]string, 0, len(d.Changes)+len(d.Warnings))
+	diffs = append(diffs, d.Changes...)
+	return append(diffs, d.Warnings...)
+}
+
+// Removals returns the paths of the resources the diff removes (e.g. "functions/test.js"),
+// which are the ones it reports the old version of without a new version
+func (d DiffResult) Removals() []string {
+	var oldPaths []string
+	newPaths := map[string]struct{}{}
+	for _, diff := range d.Changes {
+		for _, line := range diffHeaders(diff) {
+			path, _ := diffPath(line)
+			if strings.HasPrefix(line, diffOldPathPrefix) {
+				oldPaths = append(oldPaths, path)
+			} else {
+				newPaths[path] = struct{}{}
+			}
+		}
+	}
+
+	var removals []string
+	for _, path := range oldPaths {
+		if _, ok := newPaths[path]; !ok {
+			removals = append(removals, path)
+		}
+	}
+	return removals
+}
+
+// Grouped returns the changes grouped by the category of the resource they are for, which is the
+// top level directory of its path (e.g. "functions" or "triggers"); changes to the app's root
+// config files are grouped under "app" and the ones that are not for a resource under "other"
+func (d DiffResult) Grouped() map[string][]string {
+	groups := map[string][]string{}
+
+	category := diffCategoryOther
+	for _, diff := range d.Changes {
+		if headers := diffHeaders(diff); len(headers) > 0 {
+			path, _ := diffPath(headers[0])
+			category = diffCategory(path)
+		}
+		groups[category] = append(groups[category], diff)
+	}
+	return groups
+}
+
+// diffHeaders returns the lines of the diff marking the old or new version of a resource,
+// which are its first line and the lines that follow another header or precede a new version
+// or hunk; any other line starting like a header is a changed line of the resource's contents
+// (e.g. a removed "-- comment" line)
+func diffHeaders(diff string) []string {
+	lines := strings.Split(diff, "\n")
+
+	var headers []string
+	var prevHeader bool
+	for i, line := range lines {
+		_, ok := diffPath(line)
+		header := ok && (i == 0 ||
+			(prevHeader && strings.HasPrefix(line, diffNewPathPrefix)) ||
+			(i+1 < len(lines) && (strings.HasPrefix(lines[i+1], diffNewPathPrefix) || strings.HasPrefix(lines[i+1], diffHunkPrefix))))
+		if header {
+			headers = append(headers, line)
+		}
+		prevHeader = header
+	}
+	return headers
+}
+
+// diffPath returns the path of the resource a diff header line is for, if it is one
+func diffPath(line string) (string, bool) {
+	for _, prefix := range []string{diffOldPathPrefix, diffNewPathPrefix} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+		}
+	}
+	return "", false
+}
+
+func diffCategory(path string) string {
+	idx := strings.Index(path, "/")
+	if idx == -1 {
+		return diffCategoryApp
+	}
+	return path[:idx]
+}
+
 // AppDraftDiff are the diffs for a Realm app draft and its corresponding app
 type AppDraftDiff struct {
 	Diffs             []string          `json:"diffs"`
@@ -0,0 +1,113 @@
+package realm
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestNewDiffResult(t *testing.T) {
+	result := newDiffResult([]string{
+		"--- functions/test.js",
+		"+++ functions/test.js",
+		"Warning: the schema for 'db.coll' has no title",
+		"  note: the app will be redeployed",
+		"- exports = function(){ return 1; };",
+		"+ exports = function(){ return 2; };",
+	})
+
+	t.Run("should separate the warnings from the changes", func(t *testing.T) {
+		assert.Equal(t, DiffResult{
+			Changes: []string{
+				"--- functions/test.js",
+				"+++ functions/test.js",
+				"- exports = function(){ return 1; };",
+				"+ exports = function(){ return 2; };",
+			},
+			Warnings: []string{
+				"Warning: the schema for 'db.coll' has no title",
+				"  note: the app will be redeployed",
+			},
+		}, result)
+		assert.True(t, result.HasChanges(), "expected diff to have changes")
+	})
+
+	t.Run("should flatten the changes followed by the warnings", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"--- functions/test.js",
+			"+++ functions/test.js",
+			"- exports = function(){ return 1; };",
+			"+ exports = function(){ return 2; };",
+			"Warning: the schema for 'db.coll' has no title",
+			"  note: the app will be redeployed",
+		}, result.Strings())
+	})
+
+	t.Run("should report the resources removed by the diff", func(t *testing.T) {
+		result := newDiffResult([]string{
+			"--- functions/test.js",
+			"+++ functions/test.js",
+			"- exports = function(){ return 1; };",
+			"--- functions/removed.js",
+			"- exports = function(){};",
+			"+++ functions/added.js",
+			"+ exports = function(){};",
+			"--- services/mongodb-atlas/rules/db.coll.json\n-{}",
+			"warning: --- not a removal",
+		})
+		assert.Equal(t, []string{
+			"functions/removed.js",
+			"services/mongodb-atlas/rules/db.coll.json",
+		}, result.Removals())
+	})
+
+	t.Run("should not report a removed content line that looks like a header as a removal", func(t *testing.T) {
+		result := newDiffResult([]string{
+			"--- functions/test.js\n+++ functions/test.js\n@@ -1,2 +1,1 @@\n--- comment\n exports = function(){};",
+			"--- functions/removed.js\n-exports = function(){};\n--- comment",
+		})
+		assert.Equal(t, []string{"functions/removed.js"}, result.Removals())
+	})
+
+	t.Run("should group the changes by the category of their resource", func(t *testing.T) {
+		result := newDiffResult([]string{
+			"the app will be redeployed",
+			"--- functions/test.js",
+			"+++ functions/test.js",
+			"- exports = function(){ return 1; };",
+			"+ exports = function(){ return 2; };",
+			"+++ triggers/added.json\n+{}",
+			"--- realm_config.json",
+			"+++ realm_config.json",
+			`-  "location": "US-VA"`,
+			`+  "location": "IE"`,
+			"warning: not a change",
+			"+++ functions/added.js",
+			"+ exports = function(){};",
+		})
+		assert.Equal(t, map[string][]string{
+			"other": {"the app will be redeployed"},
+			"functions": {
+				"--- functions/test.js",
+				"+++ functions/test.js",
+				"- exports = function(){ return 1; };",
+				"+ exports = function(){ return 2; };",
+				"+++ functions/added.js",
+				"+ exports = function(){};",
+			},
+			"triggers": {"+++ triggers/added.json\n+{}"},
+			"app": {
+				"--- realm_config.json",
+				"+++ realm_config.json",
+				`-  "location": "US-VA"`,
+				`+  "location": "IE"`,
+			},
+		}, result.Grouped())
+	})
+
+	t.Run("should not report changes for a diff with only warnings", func(t *testing.T) {
+		result := newDiffResult([]string{"warning: something to note"})
+		assert.False(t, result.HasChanges(), "expected diff to have no changes")
+		assert.Equal(t, []string{"warning: something to note"}, result.Strings())
+	})
+}
@@ -0,0 +1,103 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	endpointsPathPattern = appPathPattern + "/endpoints"
+	endpointPathPattern  = endpointsPathPattern + "/%s"
+
+	endpointFieldDisabled = "disabled"
+)
+
+// Endpoint is a custom HTTPS endpoint of a Realm app, which calls its function for each request to its route
+type Endpoint struct {
+	ID           string `json:"_id"`
+	Route        string `json:"route"`
+	HTTPMethod   string `json:"http_method"`
+	FunctionID   string `json:"function_id,omitempty"`
+	FunctionName string `json:"function_name,omitempty"`
+	Disabled     bool   `json:"disabled"`
+}
+
+func (c *client) Endpoints(groupID, appID string) ([]Endpoint, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(endpointsPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get endpoints", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var endpoints []Endpoint
+	if err := json.NewDecoder(res.Body).Decode(&endpoints); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (c *client) DisableEndpoint(groupID, appID, endpointID string) error {
+	return c.setEndpointDisabled(groupID, appID, endpointID, true, "disable endpoint")
+}
+
+func (c *client) EnableEndpoint(groupID, appID, endpointID string) error {
+	return c.setEndpointDisabled(groupID, appID, endpointID, false, "enable endpoint")
+}
+
+// setEndpointDisabled updates the endpoint's disabled field, the endpoint is fetched
+// and sent back as a whole so that the fields the client does not model are kept as is
+func (c *client) setEndpointDisabled(groupID, appID, endpointID string, disabled bool, action string) error {
+	endpoint, err := c.endpoint(groupID, appID, endpointID)
+	if err != nil {
+		return err
+	}
+	endpoint[endpointFieldDisabled] = disabled
+
+	res, resErr := c.doJSON(
+		http.MethodPut,
+		fmt.Sprintf(endpointPathPattern, groupID, appID, endpointID),
+		endpoint,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{action, res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) endpoint(groupID, appID, endpointID string) (map[string]interface{}, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(endpointPathPattern, groupID, appID, endpointID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get endpoint", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	dec := json.NewDecoder(res.Body)
+	dec.UseNumber()
+
+	var endpoint map[string]interface{}
+	if err := dec.Decode(&endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,62 @@
+package realm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientEndpointToggle(t *testing.T) {
+	const endpointPath = "/api/admin/v3.0/groups/groupID/apps/appID/endpoints/endpointID"
+
+	newEndpointClient := func(endpoint string, requests *[]string, updated *string) Client {
+		return newTestClient(func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+
+			switch {
+			case req.URL.Path == endpointPath && req.Method == http.MethodGet:
+				return testResponse(req, http.StatusOK, endpoint), nil
+			case req.URL.Path == endpointPath && req.Method == http.MethodPut:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				*updated = string(body)
+				return testResponse(req, http.StatusNoContent, ""), nil
+			}
+			return testResponse(req, http.StatusNotFound, ""), nil
+		})
+	}
+
+	t.Run("should disable the endpoint by updating it with the disabled field set", func(t *testing.T) {
+		var requests []string
+		var updated string
+		client := newEndpointClient(`{"_id":"endpointID","route":"/items","http_method":"GET","function_id":"functionID","validation_method":"NO_VALIDATION","disabled":false}`, &requests, &updated)
+
+		assert.Nil(t, client.DisableEndpoint("groupID", "appID", "endpointID"))
+		assert.Equal(t, []string{"GET " + endpointPath, "PUT " + endpointPath}, requests)
+		assert.Equal(t, `{"_id":"endpointID","disabled":true,"function_id":"functionID","http_method":"GET","route":"/items","validation_method":"NO_VALIDATION"}`, updated)
+	})
+
+	t.Run("should enable the endpoint by updating it with the disabled field unset", func(t *testing.T) {
+		var requests []string
+		var updated string
+		client := newEndpointClient(`{"_id":"endpointID","route":"/items","http_method":"GET","function_id":"functionID","disabled":true}`, &requests, &updated)
+
+		assert.Nil(t, client.EnableEndpoint("groupID", "appID", "endpointID"))
+		assert.Equal(t, []string{"GET " + endpointPath, "PUT " + endpointPath}, requests)
+		assert.Equal(t, `{"_id":"endpointID","disabled":false,"function_id":"functionID","http_method":"GET","route":"/items"}`, updated)
+	})
+
+	t.Run("should fail when the endpoint cannot be found", func(t *testing.T) {
+		var requests []string
+		var updated string
+		client := newEndpointClient(`{}`, &requests, &updated)
+
+		assert.NotNil(t, client.DisableEndpoint("groupID", "appID", "otherID"))
+		assert.Equal(t, []string{"GET /api/admin/v3.0/groups/groupID/apps/appID/endpoints/otherID"}, requests)
+		assert.Equal(t, "", updated)
+	})
+}
@@ -4,21 +4,32 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cli/user"
 )
 
 // set of known error codes
 const (
-	errCodeInvalidSession = "InvalidSession"
+	errCodeAuthProviderNotFound = "AuthProviderNotFound"
+	errCodeInvalidSession       = "InvalidSession"
+	errCodeUserAlreadyConfirmed = "UserAlreadyConfirmed"
+	errCodeUserpassTokenInvalid = "UserpassTokenInvalid"
 
 	ErrCodeDraftAlreadyExists = "DraftAlreadyExists"
 )
 
 // set of known Realm errors
 var (
-	ErrDraftNotFound = errors.New("failed to find draft")
+	ErrDraftNotFound     = errors.New("failed to find draft")
+	ErrGraphQLNotEnabled = errors.New("graphql is not enabled for the app, make sure it has at least one collection schema")
+	ErrSyncNotEnabled    = errors.New("sync is not enabled for the app, make sure one of its cluster data sources is set up for sync")
+
+	errHTTP2Required = errors.New("request requires HTTP/2")
 )
 
 // ErrInvalidSession is an invalid session error
@@ -37,6 +48,247 @@ func (err ErrInvalidSession) Suggestions() []interface{} {
 	return []interface{}{suggestion}
 }
 
+// ErrAppNotFound is an app not found error
+type ErrAppNotFound struct {
+	ClientAppID string
+}
+
+func (err ErrAppNotFound) Error() string {
+	return fmt.Sprintf("failed to find app: '%s'", err.ClientAppID)
+}
+
+// ErrAppStateUnchanged is an error for when an app is already in the requested state
+type ErrAppStateUnchanged struct {
+	ClientAppID string
+	Disabled    bool
+}
+
+func (err ErrAppStateUnchanged) Error() string {
+	state := "enabled"
+	if err.Disabled {
+		state = "disabled"
+	}
+	return fmt.Sprintf("app '%s' is already %s", err.ClientAppID, state)
+}
+
+// ErrAppsDecode is an error for when some apps in a list fail to decode,
+// the apps that decoded successfully are still returned alongside it
+type ErrAppsDecode struct {
+	Errors []error
+}
+
+func (err ErrAppsDecode) Error() string {
+	errs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		errs[i] = e.Error()
+	}
+	return fmt.Sprintf("failed to decode %d app(s): %s", len(err.Errors), strings.Join(errs, "; "))
+}
+
+// merge collects the errors of the provided error if it is an ErrAppsDecode,
+// and reports whether it was
+func (err *ErrAppsDecode) merge(other error) bool {
+	decodeErr, ok := other.(ErrAppsDecode)
+	if !ok {
+		return false
+	}
+	err.Errors = append(err.Errors, decodeErr.Errors...)
+	return true
+}
+
+// ErrMissingVariables is an error for when variables referenced by the app data have no value
+type ErrMissingVariables struct {
+	Names []string
+}
+
+func (err ErrMissingVariables) Error() string {
+	return fmt.Sprintf("missing values for variables: %s", strings.Join(err.Names, ", "))
+}
+
+// ErrRetriesExhausted is an error for when a request is still failing after its last retry,
+// the status code of the final attempt is only set when it got a response
+type ErrRetriesExhausted struct {
+	Attempts   int
+	StatusCode int
+	Err        error
+}
+
+func (err ErrRetriesExhausted) Error() string {
+	if err.StatusCode == 0 {
+		return fmt.Sprintf("request failed after %d attempts: %s", err.Attempts, err.Err)
+	}
+	return fmt.Sprintf("request failed after %d attempts with status %d: %s", err.Attempts, err.StatusCode, err.Err)
+}
+
+// Unwrap returns the error of the final attempt
+func (err ErrRetriesExhausted) Unwrap() error {
+	return err.Err
+}
+
+// ErrWaitTimeout is an error for when waiting on an async operation to complete times out,
+// which does not mean the operation failed as it may still be in progress
+type ErrWaitTimeout struct {
+	Timeout time.Duration
+}
+
+func (err ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for the operation to complete", err.Timeout)
+}
+
+// ErrDeploymentFailed is an error for when a deployment completes with a failed status
+type ErrDeploymentFailed struct {
+	ID      string
+	Message string
+}
+
+func (err ErrDeploymentFailed) Error() string {
+	return fmt.Sprintf("deployment '%s' failed: %s", err.ID, err.Message)
+}
+
+// ErrDependenciesFailed is an error for when installing the app's dependencies fails
+type ErrDependenciesFailed struct {
+	Message string
+}
+
+func (err ErrDependenciesFailed) Error() string {
+	return fmt.Sprintf("failed to install dependencies: %s", err.Message)
+}
+
+// ErrImportNotConverged is an error for when an import succeeded but the app
+// still differs from the imported app data, the remaining diffs are included
+type ErrImportNotConverged struct {
+	Diffs []string
+}
+
+func (err ErrImportNotConverged) Error() string {
+	return fmt.Sprintf("app still has %d diff(s) after import:\n%s", len(err.Diffs), strings.Join(err.Diffs, "\n"))
+}
+
+// BatchError is an error for when some items of a batch operation fail,
+// the errors are keyed by the id of the app they occurred for
+type BatchError struct {
+	Errors    map[string]error
+	Succeeded int
+}
+
+func (err BatchError) Error() string {
+	ids := make([]string, 0, len(err.Errors))
+	for id := range err.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	errs := make([]string, len(ids))
+	for i, id := range ids {
+		errs[i] = fmt.Sprintf("%s: %s", id, err.Errors[id])
+	}
+	return fmt.Sprintf("failed %d of %d app(s): %s", len(err.Errors), len(err.Errors)+err.Succeeded, strings.Join(errs, "; "))
+}
+
+// Partial returns whether some items of the batch operation succeeded
+func (err BatchError) Partial() bool {
+	return err.Succeeded > 0
+}
+
+// ErrAuthProviderNotFound is an auth provider not found error
+type ErrAuthProviderNotFound struct {
+	ID string
+}
+
+func (err ErrAuthProviderNotFound) Error() string {
+	return fmt.Sprintf("failed to find auth provider: '%s'", err.ID)
+}
+
+// ErrFunctionNotFound is a function not found error
+type ErrFunctionNotFound struct {
+	Name string
+}
+
+func (err ErrFunctionNotFound) Error() string {
+	return fmt.Sprintf("failed to find function: '%s'", err.Name)
+}
+
+// ErrSchemaNotFound is a schema not found error
+type ErrSchemaNotFound struct {
+	ServiceID  string
+	Database   string
+	Collection string
+}
+
+func (err ErrSchemaNotFound) Error() string {
+	return fmt.Sprintf("failed to find schema: '%s.%s'", err.Database, err.Collection)
+}
+
+// ErrValueNotFound is a value not found error
+type ErrValueNotFound struct {
+	Name string
+}
+
+func (err ErrValueNotFound) Error() string {
+	return fmt.Sprintf("failed to find value: '%s'", err.Name)
+}
+
+// ErrUserAlreadyConfirmed is an error for when a pending user has already been confirmed
+type ErrUserAlreadyConfirmed struct {
+	Email string
+}
+
+func (err ErrUserAlreadyConfirmed) Error() string {
+	return fmt.Sprintf("user '%s' is already confirmed", err.Email)
+}
+
+// ErrUserTokenExpired is an error for when a user's confirmation or password reset token has expired
+type ErrUserTokenExpired struct {
+	Email string
+}
+
+func (err ErrUserTokenExpired) Error() string {
+	return fmt.Sprintf("the token for user '%s' has expired or is invalid", err.Email)
+}
+
+// ErrPermissionDenied is an error for when the user lacks the permissions to access a resource,
+// the role required to access it is only set when provided by the server
+type ErrPermissionDenied struct {
+	Resource     string
+	RequiredRole string
+	ServerError  ServerError
+}
+
+func (err ErrPermissionDenied) Error() string {
+	msg := fmt.Sprintf("permission denied for '%s'", err.Resource)
+	if err.RequiredRole != "" {
+		msg += fmt.Sprintf(" (requires role '%s')", err.RequiredRole)
+	}
+	if err.ServerError.Message != "" {
+		msg += ": " + err.ServerError.Message
+	}
+	return msg
+}
+
+// Unwrap returns the underlying server error
+func (err ErrPermissionDenied) Unwrap() error {
+	return err.ServerError
+}
+
+// ErrInvalidResourcePath is an error for when a resource path does not stay within the app's resources
+type ErrInvalidResourcePath struct {
+	Path string
+}
+
+func (err ErrInvalidResourcePath) Error() string {
+	return fmt.Sprintf("invalid resource path: '%s'", err.Path)
+}
+
+// ErrUntrustedRedirect is an error for when a request is redirected to a host that is not trusted,
+// the redirect is not followed so that the request's credentials are not sent to the host
+type ErrUntrustedRedirect struct {
+	Host string
+}
+
+func (err ErrUntrustedRedirect) Error() string {
+	return fmt.Sprintf("refused to follow redirect to untrusted host: '%s'", err.Host)
+}
+
 // ServerError is a Realm server error
 type ServerError struct {
 	Code    string `json:"error_code"`
@@ -48,8 +300,9 @@ func (se ServerError) Error() string {
 }
 
 // parseResponseError attempts to read and unmarshal a server error
-// from the provided *http.Response
-func parseResponseError(res *http.Response) error {
+// from the provided *http.Response, a payload failing to unmarshal is
+// used as the error message unless strict, in which case the decode error is returned
+func parseResponseError(res *http.Response, strict bool) error {
 	buf := new(bytes.Buffer)
 	if _, err := buf.ReadFrom(res.Body); err != nil {
 		return err
@@ -62,7 +315,34 @@ func parseResponseError(res *http.Response) error {
 
 	var serverError ServerError
 	if err := json.NewDecoder(buf).Decode(&serverError); err != nil {
+		if strict {
+			return fmt.Errorf("failed to decode error response (%s): %w", res.Status, err)
+		}
 		serverError.Message = payload
 	}
+
+	if res.StatusCode == http.StatusForbidden {
+		return newErrPermissionDenied(res, payload, serverError)
+	}
 	return serverError
 }
+
+// newErrPermissionDenied creates a permission denied error for the response's resource,
+// including the role required to access it when the server provides it
+func newErrPermissionDenied(res *http.Response, payload string, serverError ServerError) ErrPermissionDenied {
+	var details struct {
+		RequiredRole string `json:"required_role"`
+	}
+	json.Unmarshal([]byte(payload), &details) //nolint: errcheck
+
+	var resource string
+	if res.Request != nil && res.Request.URL != nil {
+		resource = res.Request.URL.Path
+	}
+
+	return ErrPermissionDenied{
+		Resource:     resource,
+		RequiredRole: details.RequiredRole,
+		ServerError:  serverError,
+	}
+}
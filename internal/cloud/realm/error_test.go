@@ -1,8 +1,11 @@
 package realm
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -17,7 +20,7 @@ func TestServerError(t *testing.T) {
 	t.Run("Should unmarshal a non-json response successfully", func(t *testing.T) {
 		err := parseResponseError(&http.Response{
 			Body: ioutil.NopCloser(strings.NewReader("something bad happened")),
-		})
+		}, false)
 		assert.Equal(t, ServerError{Message: "something bad happened"}, err)
 	})
 
@@ -25,7 +28,7 @@ func TestServerError(t *testing.T) {
 		err := parseResponseError(&http.Response{
 			Status: "something bad happened",
 			Body:   ioutil.NopCloser(strings.NewReader("")),
-		})
+		}, false)
 		assert.Equal(t, ServerError{Message: "something bad happened"}, err)
 	})
 
@@ -33,7 +36,7 @@ func TestServerError(t *testing.T) {
 		err := parseResponseError(&http.Response{
 			Body:   ioutil.NopCloser(strings.NewReader(`{"error": "something bad happened"}`)),
 			Header: jsonContentTypeHeader,
-		})
+		}, false)
 		assert.Equal(t, ServerError{Message: "something bad happened"}, err)
 	})
 
@@ -41,7 +44,83 @@ func TestServerError(t *testing.T) {
 		err := parseResponseError(&http.Response{
 			Body:   ioutil.NopCloser(strings.NewReader(`{"error": "something bad happened","error_code": "AnErrorCode"}`)),
 			Header: jsonContentTypeHeader,
-		})
+		}, false)
 		assert.Equal(t, ServerError{Code: "AnErrorCode", Message: "something bad happened"}, err)
 	})
+
+	t.Run("Should create a permission denied error from a forbidden response", func(t *testing.T) {
+		err := parseResponseError(&http.Response{
+			StatusCode: http.StatusForbidden,
+			Request:    &http.Request{URL: &url.URL{Path: "/api/admin/v3.0/groups/groupID/apps"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"error": "insufficient permissions","error_code": "Forbidden","required_role":"GROUP_OWNER"}`)),
+			Header:     jsonContentTypeHeader,
+		}, false)
+		assert.Equal(t, ErrPermissionDenied{
+			Resource:     "/api/admin/v3.0/groups/groupID/apps",
+			RequiredRole: "GROUP_OWNER",
+			ServerError:  ServerError{Code: "Forbidden", Message: "insufficient permissions"},
+		}, err)
+		assert.Equal(t, "permission denied for '/api/admin/v3.0/groups/groupID/apps' (requires role 'GROUP_OWNER'): insufficient permissions", err.Error())
+	})
+
+	t.Run("Should create a permission denied error from a forbidden response without a required role", func(t *testing.T) {
+		err := parseResponseError(&http.Response{
+			StatusCode: http.StatusForbidden,
+			Body:       ioutil.NopCloser(strings.NewReader("forbidden")),
+		}, false)
+		assert.Equal(t, ErrPermissionDenied{ServerError: ServerError{Message: "forbidden"}}, err)
+
+		var serverErr ServerError
+		assert.True(t, errors.As(err, &serverErr), "expected error to wrap a server error")
+	})
+
+	t.Run("Should fail with the decode error of a non-json response when strict", func(t *testing.T) {
+		err := parseResponseError(&http.Response{
+			Status: "500 Internal Server Error",
+			Body:   ioutil.NopCloser(strings.NewReader("something bad happened")),
+		}, true)
+		assert.Equal(t, "failed to decode error response (500 Internal Server Error): invalid character 's' looking for beginning of value", err.Error())
+	})
+
+	t.Run("Should unmarshal a server error payload successfully when strict", func(t *testing.T) {
+		err := parseResponseError(&http.Response{
+			Body:   ioutil.NopCloser(strings.NewReader(`{"error": "something bad happened","error_code": "AnErrorCode"}`)),
+			Header: jsonContentTypeHeader,
+		}, true)
+		assert.Equal(t, ServerError{Code: "AnErrorCode", Message: "something bad happened"}, err)
+	})
+}
+
+func TestErrImportNotConverged(t *testing.T) {
+	t.Run("should list the remaining diffs", func(t *testing.T) {
+		err := ErrImportNotConverged{[]string{"+ function: test", "- trigger: yell"}}
+		assert.Equal(t, "app still has 2 diff(s) after import:\n+ function: test\n- trigger: yell", err.Error())
+	})
+}
+
+func TestBatchError(t *testing.T) {
+	t.Run("Should report each failed app sorted by id", func(t *testing.T) {
+		err := BatchError{
+			Errors: map[string]error{
+				"app2": errors.New("something bad happened"),
+				"app1": ServerError{Message: "something else bad happened"},
+			},
+			Succeeded: 1,
+		}
+		assert.Equal(t, "failed 2 of 3 app(s): app1: something else bad happened; app2: something bad happened", err.Error())
+		assert.True(t, err.Partial(), "expected batch error to be partial")
+	})
+
+	t.Run("Should not be partial when no app succeeded", func(t *testing.T) {
+		err := BatchError{Errors: map[string]error{"app1": errors.New("something bad happened")}}
+		assert.False(t, err.Partial(), "expected batch error to not be partial")
+	})
+
+	t.Run("Should be extracted with errors.As", func(t *testing.T) {
+		var err error = fmt.Errorf("failed to import: %w", BatchError{Errors: map[string]error{"app1": errors.New("something bad happened")}})
+
+		var batchErr BatchError
+		assert.True(t, errors.As(err, &batchErr), "expected error to be a batch error")
+		assert.Equal(t, errors.New("something bad happened"), batchErr.Errors["app1"])
+	})
 }
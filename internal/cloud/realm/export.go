@@ -1,13 +1,24 @@
 package realm
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
 )
@@ -21,17 +32,296 @@ const (
 
 	mediaParamFilename = "filename"
 
+	digestAlgorithmSHA256 = "sha-256"
+	rangeUnitBytes        = "bytes"
+
+	exportMaxResumeAttempts = 3
+
+	exportSecretStubsFile = "secret_stubs.json"
+
+	exportJSONIndent = "    "
+	extJSON          = ".json"
+
+	// defaultMaxExportSize is the default maximum size in bytes of a downloaded export archive
+	defaultMaxExportSize int64 = 256 << 20
+
 	trueVal = "true"
 )
 
+// DefaultExportVolatileFields is the default set of fields removed
+// from the exported json files when omitting volatile fields
+var DefaultExportVolatileFields = []string{
+	"created_at",
+	"last_modified",
+	"last_used",
+	"updated_at",
+}
+
+// exportTarModTime is the modification time set on every entry of an export tarball
+var exportTarModTime = time.Unix(0, 0).UTC()
+
+// set of export errors
+var (
+	errExportMissingChecksum = errors.New("export response is missing checksum")
+	errExportMissingFilename = errors.New("export response is missing filename")
+	errExportChecksumInvalid = errors.New("export checksum does not match the downloaded archive")
+	errExportTooLarge        = errors.New("export archive exceeds the maximum export size")
+)
+
 // ExportRequest is a Realm application export request
 type ExportRequest struct {
 	ConfigVersion AppConfigVersion
 	IsTemplated   bool
+
+	// VerifyChecksum verifies the downloaded archive against the checksum
+	// provided by the server, which is recommended whenever the export is
+	// going to be imported elsewhere
+	VerifyChecksum bool
+
+	// Canonicalize rewrites the exported json files with sorted keys and stable
+	// indentation so that semantically identical configs produce identical files,
+	// otherwise they are kept byte-for-byte as the server produced them
+	// (import payloads need no such step as they are always encoded with sorted keys)
+	Canonicalize bool
+
+	// OmitVolatileFields removes the fields that change on every export regardless
+	// of whether the app changed (e.g. timestamps) from the exported json files, so that
+	// exports committed to source control only differ when the app actually changes;
+	// the json files are canonicalized as well
+	OmitVolatileFields bool
+
+	// VolatileFields are the names of the metadata fields removed from the top level
+	// of the exported json files (or of each of their entries when a file is a list)
+	// by OmitVolatileFields, defaults to DefaultExportVolatileFields; nested fields
+	// such as schema properties or rule fields are never removed
+	VolatileFields []string
+
+	// UsePlaceholders replaces the environment specific values of the exported json
+	// files' PlaceholderFields with stable placeholders (e.g. "{{app_id}}"), so that
+	// the export can be imported into other environments by providing the values
+	// to substitute with ImportOptions.PlaceholderValues; the json files are
+	// canonicalized as well
+	UsePlaceholders bool
+
+	// PlaceholderFields maps the names of the fields replaced at any depth of the
+	// exported json files by UsePlaceholders to the name of their placeholder,
+	// defaults to DefaultExportPlaceholderFields
+	PlaceholderFields map[string]string
+
+	// IncludeSecretStubs adds a secret_stubs.json file to the export listing the
+	// names of the app's secrets, so an import target knows which secrets must be
+	// provisioned; secret values are never exported and each stub is marked as such
+	IncludeSecretStubs bool
+
+	// Context bounds the export request, including its download and any resumptions
+	// of it, which lets large exports be given a longer deadline than other requests
+	Context context.Context
+
+	// Query holds extra query parameters to include with the export request,
+	// which allows adopting new server features ahead of first-class support;
+	// parameters set through the other export request options take precedence
+	Query map[string]string
 }
 
 func (c *client) Export(groupID, appID string, req ExportRequest) (string, *zip.Reader, error) {
-	options := api.RequestOptions{Query: map[string]string{
+	filename, body, err := c.exportBytes(groupID, appID, req, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	zipPkg, zipErr := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if zipErr != nil {
+		return "", nil, zipErr
+	}
+
+	return filename, zipPkg, nil
+}
+
+func (c *client) ExportFiles(groupID, appID string, req ExportRequest) (map[string][]byte, string, error) {
+	filename, zipPkg, err := c.Export(groupID, appID, req)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := validateExportPaths(zipPkg); err != nil {
+		return nil, "", err
+	}
+
+	files, err := readExportFiles(zipPkg)
+	if err != nil {
+		return nil, "", err
+	}
+	return files, exportAppName(filename), nil
+}
+
+// readExportFiles reads the contents of each of the export archive's files into memory,
+// the archive's paths must have been validated beforehand
+func readExportFiles(zipPkg *zip.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(zipPkg.File))
+	if err := eachExportFile(zipPkg, func(name string, r io.Reader) error {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		files[name] = data
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (c *client) ExportEachFile(groupID, appID string, req ExportRequest, fn ExportFileFunc) error {
+	_, zipPkg, err := c.Export(groupID, appID, req)
+	if err != nil {
+		return err
+	}
+	if err := validateExportPaths(zipPkg); err != nil {
+		return err
+	}
+	return eachExportFile(zipPkg, fn)
+}
+
+// ExportFileFunc is called with each file of an export and a reader of its contents,
+// which is only valid until the function returns; iterating over the export stops
+// with the error it returns, if any
+type ExportFileFunc func(name string, r io.Reader) error
+
+// eachExportFile calls the function with each of the export archive's files in order,
+// directories are skipped
+func eachExportFile(zipPkg *zip.Reader, fn ExportFileFunc) error {
+	for _, file := range zipPkg.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = fn(file.Name, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *client) ExportToTarGz(groupID, appID string, req ExportRequest, w io.Writer) error {
+	_, zipPkg, err := c.Export(groupID, appID, req)
+	if err != nil {
+		return err
+	}
+	if err := validateExportPaths(zipPkg); err != nil {
+		return err
+	}
+	return writeExportTarGz(w, zipPkg)
+}
+
+// writeExportTarGz repacks the export archive as a gzipped tarball, its entries are
+// sorted by path and carry fixed timestamps and permissions so that the tarball is
+// reproducible for the same export contents
+func writeExportTarGz(w io.Writer, zipPkg *zip.Reader) error {
+	files := make([]*zip.File, len(zipPkg.File))
+	copy(files, zipPkg.File)
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+		header := tar.Header{
+			Name:    file.Name,
+			ModTime: exportTarModTime,
+			Format:  tar.FormatPAX,
+		}
+
+		if file.FileInfo().IsDir() {
+			header.Typeflag = tar.TypeDir
+			header.Mode = 0755
+			if err := tw.WriteHeader(&header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		header.Typeflag = tar.TypeReg
+		header.Mode = 0644
+		header.Size = int64(file.UncompressedSize64)
+		if err := tw.WriteHeader(&header); err != nil {
+			return err
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// validateExportPaths ensures none of the export archive's files
+// have paths that would resolve outside of the archive's root
+func validateExportPaths(zipPkg *zip.Reader) error {
+	for _, file := range zipPkg.File {
+		if !IsValidExportPath(file.Name) {
+			return fmt.Errorf("export archive contains an invalid file path: %s", file.Name)
+		}
+	}
+	return nil
+}
+
+// IsValidExportPath reports whether the slash-separated path of an export archive's entry
+// stays within the archive's root, so it must be "." or made of named elements (directories
+// may have a trailing slash), without a leading slash or backslashes; anything extracting
+// an export archive must check each of its entries with it
+func IsValidExportPath(name string) bool {
+	name = strings.TrimSuffix(name, "/")
+	if name == "." {
+		return true
+	}
+	if strings.Contains(name, `\`) {
+		return false
+	}
+	for _, elem := range strings.Split(name, "/") {
+		switch elem {
+		case "", ".", "..":
+			return false
+		}
+	}
+	return true
+}
+
+// exportAppName returns the app name from the export filename (e.g. "<app name>_<timestamp>.zip")
+func exportAppName(filename string) string {
+	name := strings.TrimSuffix(filename, filepath.Ext(filename))
+	if idx := strings.LastIndex(name, "_"); idx != -1 {
+		name = name[:idx]
+	}
+	return name
+}
+
+func (c *client) ExportBytes(groupID, appID string, req ExportRequest) (string, []byte, error) {
+	maxSize := c.options.MaxExportSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxExportSize
+	}
+	return c.exportBytes(groupID, appID, req, maxSize)
+}
+
+// exportBytes downloads the export archive, failing once it grows beyond
+// the provided maximum size unless the size is unlimited (i.e. zero)
+func (c *client) exportBytes(groupID, appID string, req ExportRequest, maxSize int64) (string, []byte, error) {
+	options := api.RequestOptions{Context: req.Context, Query: map[string]string{
 		exportQueryVersion: DefaultAppConfigVersion.String(),
 	}}
 
@@ -44,7 +334,13 @@ func (c *client) Export(groupID, appID string, req ExportRequest) (string, *zip.
 		options.Query[exportQueryForSourceControl] = trueVal
 	}
 
-	res, resErr := c.do(http.MethodGet, fmt.Sprintf(exportPathPattern, groupID, appID), options)
+	if err := includeExtraQuery(options.Query, req.Query); err != nil {
+		return "", nil, err
+	}
+
+	path := fmt.Sprintf(exportPathPattern, groupID, appID)
+
+	res, resErr := c.do(http.MethodGet, path, options)
 	if resErr != nil {
 		return "", nil, resErr
 	}
@@ -52,26 +348,340 @@ func (c *client) Export(groupID, appID string, req ExportRequest) (string, *zip.
 		return "", nil, api.ErrUnexpectedStatusCode{"export", res.StatusCode}
 	}
 
-	_, mediaParams, mediaErr := mime.ParseMediaType(res.Header.Get(api.HeaderContentDisposition))
-	if mediaErr != nil {
-		return "", nil, mediaErr
+	filename, filenameErr := exportFilename(res.Header.Get(api.HeaderContentDisposition))
+	if filenameErr != nil {
+		return "", nil, filenameErr
+	}
+
+	body, bodyErr := c.downloadExport(res, path, options, maxSize)
+	if bodyErr != nil {
+		return "", nil, bodyErr
+	}
+
+	if req.VerifyChecksum {
+		if err := verifyExportChecksum(res.Header.Get(api.HeaderDigest), body); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if req.IncludeSecretStubs {
+		secrets, err := c.Secrets(groupID, appID)
+		if err != nil {
+			return "", nil, err
+		}
+
+		stubbedBody, err := addExportSecretStubs(body, secrets)
+		if err != nil {
+			return "", nil, err
+		}
+		body = stubbedBody
+	}
+
+	if req.Canonicalize || req.OmitVolatileFields || req.UsePlaceholders {
+		var opts exportJSONOptions
+		if req.OmitVolatileFields {
+			opts.omitFields = req.VolatileFields
+			if len(opts.omitFields) == 0 {
+				opts.omitFields = DefaultExportVolatileFields
+			}
+		}
+		if req.UsePlaceholders {
+			opts.placeholderFields = req.PlaceholderFields
+			if len(opts.placeholderFields) == 0 {
+				opts.placeholderFields = DefaultExportPlaceholderFields
+			}
+		}
+
+		canonicalBody, err := canonicalizeExport(body, opts)
+		if err != nil {
+			return "", nil, err
+		}
+		body = canonicalBody
+	}
+
+	return filename, body, nil
+}
+
+// exportFilename reads the export filename from the provided Content-Disposition header,
+// failing the same way whether the header is missing, malformed or lacks the filename
+func exportFilename(contentDisposition string) (string, error) {
+	if contentDisposition == "" {
+		return "", errExportMissingFilename
+	}
+
+	_, mediaParams, err := mime.ParseMediaType(contentDisposition)
+	if err != nil {
+		return "", errExportMissingFilename
 	}
 
 	filename := mediaParams[mediaParamFilename]
 	if filename == "" {
-		return "", nil, errors.New("export response is missing filename")
+		return "", errExportMissingFilename
 	}
+	return filename, nil
+}
 
-	defer res.Body.Close()
-	body, bodyErr := ioutil.ReadAll(res.Body)
-	if bodyErr != nil {
-		return "", nil, bodyErr
+// downloadExport reads the export archive from the response body and,
+// if the download is interrupted, resumes it from where it left off
+// provided the server supports range requests
+func (c *client) downloadExport(res *http.Response, path string, options api.RequestOptions, maxSize int64) ([]byte, error) {
+	var body bytes.Buffer
+
+	err := readExport(&body, res.Body, maxSize)
+	res.Body.Close()
+	if err == nil {
+		return body.Bytes(), nil
 	}
 
-	zipPkg, zipErr := zip.NewReader(bytes.NewReader(body), int64(len(body)))
-	if zipErr != nil {
-		return "", nil, zipErr
+	if err == errExportTooLarge || res.Header.Get(api.HeaderAcceptRanges) != rangeUnitBytes {
+		return nil, err
 	}
 
-	return filename, zipPkg, nil
+	for attempt := 0; attempt < exportMaxResumeAttempts; attempt++ {
+		options.Headers = map[string]string{
+			api.HeaderRange: fmt.Sprintf("%s=%d-", rangeUnitBytes, body.Len()),
+		}
+
+		resumed, resumeErr := c.do(http.MethodGet, path, options)
+		if resumeErr != nil {
+			return nil, resumeErr
+		}
+		if resumed.StatusCode != http.StatusPartialContent {
+			resumed.Body.Close()
+			return nil, api.ErrUnexpectedStatusCode{"resume export", resumed.StatusCode}
+		}
+
+		err = readExport(&body, resumed.Body, maxSize)
+		resumed.Body.Close()
+		if err == nil {
+			return body.Bytes(), nil
+		}
+		if err == errExportTooLarge {
+			break
+		}
+	}
+	return nil, err
+}
+
+// readExport appends the export archive contents read from r to the buffer,
+// failing once the buffer would grow beyond the provided maximum size, if any
+func readExport(body *bytes.Buffer, r io.Reader, maxSize int64) error {
+	if maxSize <= 0 {
+		_, err := body.ReadFrom(r)
+		return err
+	}
+
+	remaining := maxSize - int64(body.Len())
+	if _, err := body.ReadFrom(io.LimitReader(r, remaining+1)); err != nil {
+		return err
+	}
+	if int64(body.Len()) > maxSize {
+		return errExportTooLarge
+	}
+	return nil
+}
+
+// verifyExportChecksum verifies the export archive against the SHA-256 value
+// found in the provided Digest header (e.g. "SHA-256=<base64 encoded sum>")
+func verifyExportChecksum(digest string, body []byte) error {
+	var expected string
+	for _, part := range strings.Split(digest, ",") {
+		idx := strings.Index(part, "=")
+		if idx == -1 {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(part[:idx]), digestAlgorithmSHA256) {
+			expected = strings.TrimSpace(part[idx+1:])
+			break
+		}
+	}
+
+	if expected == "" {
+		return errExportMissingChecksum
+	}
+
+	sum := sha256.Sum256(body)
+	if base64.StdEncoding.EncodeToString(sum[:]) != expected {
+		return errExportChecksumInvalid
+	}
+	return nil
+}
+
+// SecretStub is a placeholder for an app secret included in an export,
+// it only holds the secret's name as secret values are never exported
+type SecretStub struct {
+	Name string `json:"name"`
+	Stub bool   `json:"stub"`
+}
+
+// addExportSecretStubs rewrites the export archive with an additional file
+// listing a stub for each of the provided secrets
+func addExportSecretStubs(body []byte, secrets []Secret) ([]byte, error) {
+	zipPkg, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, file := range zipPkg.File {
+		if file.Name == exportSecretStubsFile {
+			continue
+		}
+		if err := copyZipFile(w, file, file.FileHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	stubs := make([]SecretStub, 0, len(secrets))
+	for _, secret := range secrets {
+		stubs = append(stubs, SecretStub{Name: secret.Name, Stub: true})
+	}
+
+	data, err := json.MarshalIndent(stubs, "", exportJSONIndent)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := w.Create(exportSecretStubsFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportJSONOptions are the options to rewrite the exported json files with
+type exportJSONOptions struct {
+	omitFields        []string
+	placeholderFields map[string]string
+}
+
+// canonicalizeExport rewrites the export archive with each of its json files canonicalized
+// according to the provided options, all other files are left untouched
+func canonicalizeExport(body []byte, opts exportJSONOptions) ([]byte, error) {
+	zipPkg, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, file := range zipPkg.File {
+		header := file.FileHeader
+		if !strings.HasSuffix(file.Name, extJSON) || file.FileInfo().IsDir() {
+			if err := copyZipFile(w, file, header); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		fw, err := w.CreateHeader(&header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(canonicalJSON(data, opts)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyZipFile(w *zip.Writer, file *zip.File, header zip.FileHeader) error {
+	fw, err := w.CreateHeader(&header)
+	if err != nil {
+		return err
+	}
+	if file.FileInfo().IsDir() {
+		return nil
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(fw, r)
+	return err
+}
+
+// CanonicalJSON re-encodes the json data with sorted keys and stable indentation,
+// data that fails to parse is returned as is
+func CanonicalJSON(data []byte) []byte {
+	return canonicalJSON(data, exportJSONOptions{})
+}
+
+// canonicalJSON re-encodes the json data with sorted keys and stable indentation
+// after removing and templating the fields set in the provided options,
+// data that fails to parse is returned as is
+func canonicalJSON(data []byte, opts exportJSONOptions) []byte {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+
+	if len(opts.omitFields) > 0 {
+		fields := make(map[string]struct{}, len(opts.omitFields))
+		for _, field := range opts.omitFields {
+			fields[field] = struct{}{}
+		}
+		omitMetadataFields(v, fields)
+	}
+	if len(opts.placeholderFields) > 0 {
+		templateJSONFields(v, opts.placeholderFields)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", exportJSONIndent)
+	if err := enc.Encode(v); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
+
+// omitMetadataFields removes the fields from the top level of the decoded json file,
+// or from each of its entries when the file is a list, leaving the nested values as is
+func omitMetadataFields(v interface{}, fields map[string]struct{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for field := range fields {
+			delete(val, field)
+		}
+	case []interface{}:
+		for _, elem := range val {
+			if entry, ok := elem.(map[string]interface{}); ok {
+				for field := range fields {
+					delete(entry, field)
+				}
+			}
+		}
+	}
 }
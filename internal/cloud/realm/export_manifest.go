@@ -0,0 +1,55 @@
+package realm
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+// ExportManifest describes an app export, so that it can be stored
+// alongside the exported archive as an audit record of where it came from
+type ExportManifest struct {
+	AppID         string           `json:"app_id"`
+	ClientAppID   string           `json:"client_app_id"`
+	Name          string           `json:"name"`
+	ConfigVersion AppConfigVersion `json:"config_version"`
+	Filename      string           `json:"filename"`
+	ExportedAt    time.Time        `json:"exported_at"`
+
+	// Checksum is the base64 encoded SHA-256 sum of the exported archive,
+	// as returned by the client after any of the export request's rewrites
+	Checksum string `json:"checksum"`
+}
+
+func (c *client) ExportWithManifest(groupID, appID string, req ExportRequest) (ExportManifest, []byte, error) {
+	app, err := c.FindApp(groupID, appID)
+	if err != nil {
+		return ExportManifest{}, nil, err
+	}
+
+	filename, body, err := c.ExportBytes(groupID, appID, req)
+	if err != nil {
+		return ExportManifest{}, nil, err
+	}
+
+	return newExportManifest(app, req, filename, body, c.now()), body, nil
+}
+
+func newExportManifest(app App, req ExportRequest, filename string, body []byte, exportedAt time.Time) ExportManifest {
+	configVersion := req.ConfigVersion
+	if configVersion == AppConfigVersionZero {
+		configVersion = DefaultAppConfigVersion
+	}
+
+	sum := sha256.Sum256(body)
+
+	return ExportManifest{
+		AppID:         app.ID,
+		ClientAppID:   app.ClientAppID,
+		Name:          app.Name,
+		ConfigVersion: configVersion,
+		Filename:      filename,
+		ExportedAt:    exportedAt.UTC(),
+		Checksum:      base64.StdEncoding.EncodeToString(sum[:]),
+	}
+}
@@ -0,0 +1,48 @@
+package realm
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestNewExportManifest(t *testing.T) {
+	app := App{ID: "appID", ClientAppID: "eggcorn-abcde", Name: "eggcorn"}
+	exportedAt := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	body := []byte("archive")
+	sum := sha256.Sum256(body)
+
+	for _, tc := range []struct {
+		description           string
+		req                   ExportRequest
+		expectedConfigVersion AppConfigVersion
+	}{
+		{
+			description:           "should describe the export with the default config version",
+			expectedConfigVersion: DefaultAppConfigVersion,
+		},
+		{
+			description:           "should describe the export with the requested config version",
+			req:                   ExportRequest{ConfigVersion: AppConfigVersion20200603},
+			expectedConfigVersion: AppConfigVersion20200603,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			manifest := newExportManifest(app, tc.req, "eggcorn_20210101120000.zip", body, exportedAt)
+
+			assert.Equal(t, ExportManifest{
+				AppID:         "appID",
+				ClientAppID:   "eggcorn-abcde",
+				Name:          "eggcorn",
+				ConfigVersion: tc.expectedConfigVersion,
+				Filename:      "eggcorn_20210101120000.zip",
+				ExportedAt:    exportedAt.UTC(),
+				Checksum:      base64.StdEncoding.EncodeToString(sum[:]),
+			}, manifest)
+		})
+	}
+}
@@ -0,0 +1,556 @@
+package realm
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestExportFilename(t *testing.T) {
+	for _, tc := range []struct {
+		description        string
+		contentDisposition string
+		expectedFilename   string
+		expectedErr        error
+	}{
+		{
+			description:        "should return the filename",
+			contentDisposition: `attachment; filename="eggcorn_20210101.zip"`,
+			expectedFilename:   "eggcorn_20210101.zip",
+		},
+		{
+			description: "should fail when the header is missing",
+			expectedErr: errExportMissingFilename,
+		},
+		{
+			description:        "should fail when the header is malformed",
+			contentDisposition: "attachment; filename",
+			expectedErr:        errExportMissingFilename,
+		},
+		{
+			description:        "should fail when the header has no filename",
+			contentDisposition: "attachment",
+			expectedErr:        errExportMissingFilename,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			filename, err := exportFilename(tc.contentDisposition)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedFilename, filename)
+		})
+	}
+}
+
+func TestExportAppName(t *testing.T) {
+	for _, tc := range []struct {
+		filename string
+		expected string
+	}{
+		{"eggcorn_20210101120000.zip", "eggcorn"},
+		{"egg_corn_20210101120000.zip", "egg_corn"},
+		{"eggcorn.zip", "eggcorn"},
+	} {
+		t.Run("should return the app name for "+tc.filename, func(t *testing.T) {
+			assert.Equal(t, tc.expected, exportAppName(tc.filename))
+		})
+	}
+}
+
+func TestValidateExportPaths(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		name        string
+		expectedErr error
+	}{
+		{description: "should allow a file at the root", name: "realm_config.json"},
+		{description: "should allow a nested file", name: "functions/test.js"},
+		{description: "should allow a directory", name: "functions/"},
+		{
+			description: "should reject a file escaping the root",
+			name:        "../../etc/passwd",
+			expectedErr: errors.New("export archive contains an invalid file path: ../../etc/passwd"),
+		},
+		{
+			description: "should reject a nested file escaping the root",
+			name:        "functions/../../test.js",
+			expectedErr: errors.New("export archive contains an invalid file path: functions/../../test.js"),
+		},
+		{
+			description: "should reject an absolute file path",
+			name:        "/etc/passwd",
+			expectedErr: errors.New("export archive contains an invalid file path: /etc/passwd"),
+		},
+		{
+			description: "should reject a windows file path escaping the root",
+			name:        `..\..\evil.exe`,
+			expectedErr: errors.New(`export archive contains an invalid file path: ..\..\evil.exe`),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := zip.NewWriter(&buf)
+			_, err := w.Create(tc.name)
+			assert.Nil(t, err)
+			assert.Nil(t, w.Close())
+
+			zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedErr, validateExportPaths(zipPkg))
+		})
+	}
+}
+
+func TestDownloadExportResume(t *testing.T) {
+	const exportPath = "/api/admin/v3.0/groups/groupID/apps/appID/export"
+
+	var ranges []string
+
+	c := newTestClient(func(req *http.Request) (*http.Response, error) {
+		ranges = append(ranges, req.Header.Get(api.HeaderRange))
+		return testResponse(req, http.StatusPartialContent, "-archive"), nil
+	})
+
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{api.HeaderAcceptRanges: []string{rangeUnitBytes}},
+		Body:       ioutil.NopCloser(io.MultiReader(strings.NewReader("export"), errReader{io.ErrUnexpectedEOF})),
+	}
+
+	body, err := c.downloadExport(res, exportPath, api.RequestOptions{}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "export-archive", string(body))
+	assert.Equal(t, []string{"bytes=6-"}, ranges)
+}
+
+// errReader is a reader failing with its error, which simulates a connection cut short
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestVerifyExportChecksum(t *testing.T) {
+	body := []byte("export archive contents")
+
+	sum := sha256.Sum256(body)
+	checksum := base64.StdEncoding.EncodeToString(sum[:])
+
+	for _, tc := range []struct {
+		description string
+		digest      string
+		expectedErr error
+	}{
+		{
+			description: "should succeed when the checksum matches",
+			digest:      "SHA-256=" + checksum,
+		},
+		{
+			description: "should succeed when the checksum matches among other digests",
+			digest:      "MD5=abc123, sha-256=" + checksum,
+		},
+		{
+			description: "should fail when the checksum does not match",
+			digest:      "SHA-256=bm90IHRoZSBzdW0=",
+			expectedErr: errExportChecksumInvalid,
+		},
+		{
+			description: "should fail when the checksum is missing",
+			digest:      "MD5=abc123",
+			expectedErr: errExportMissingChecksum,
+		},
+		{
+			description: "should fail when the digest header is empty",
+			expectedErr: errExportMissingChecksum,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, verifyExportChecksum(tc.digest, body))
+		})
+	}
+}
+
+func TestReadExport(t *testing.T) {
+	for _, tc := range []struct {
+		description  string
+		existing     string
+		contents     string
+		maxSize      int64
+		expectedBody string
+		expectedErr  error
+	}{
+		{
+			description:  "should read the contents when under the maximum size",
+			contents:     "archive",
+			maxSize:      10,
+			expectedBody: "archive",
+		},
+		{
+			description:  "should read the contents when exactly the maximum size",
+			contents:     "archive",
+			maxSize:      7,
+			expectedBody: "archive",
+		},
+		{
+			description:  "should append the contents to what was previously read",
+			existing:     "arch",
+			contents:     "ive",
+			maxSize:      7,
+			expectedBody: "archive",
+		},
+		{
+			description:  "should read the contents without a maximum size",
+			existing:     "arch",
+			contents:     "ive",
+			expectedBody: "archive",
+		},
+		{
+			description: "should fail when the contents exceed the maximum size",
+			contents:    "archive",
+			maxSize:     6,
+			expectedErr: errExportTooLarge,
+		},
+		{
+			description: "should fail when the appended contents exceed the maximum size",
+			existing:    "arch",
+			contents:    "ive",
+			maxSize:     6,
+			expectedErr: errExportTooLarge,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			body := bytes.NewBufferString(tc.existing)
+
+			err := readExport(body, strings.NewReader(tc.contents), tc.maxSize)
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expectedBody, body.String())
+			}
+		})
+	}
+}
+
+func TestCanonicalizeExport(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name     string
+		contents string
+	}{
+		{"functions/", ""},
+		{"functions/test.js", "exports = function(){ return {b: 1, a: 2}; };"},
+		{"realm_config.json", `{"name":"eggcorn","config_version":20210101,"nested":{"z":1.50,"a":"<&>"}}`},
+		{"invalid.json", `{"name":`},
+	} {
+		f, err := w.Create(file.name)
+		assert.Nil(t, err)
+		_, err = f.Write([]byte(file.contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+
+	body, err := canonicalizeExport(buf.Bytes(), exportJSONOptions{})
+	assert.Nil(t, err)
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.Nil(t, err)
+
+	files := map[string]string{}
+	for _, file := range zipPkg.File {
+		r, err := file.Open()
+		assert.Nil(t, err)
+		data, err := ioutil.ReadAll(r)
+		assert.Nil(t, err)
+		files[file.Name] = string(data)
+	}
+
+	assert.Equal(t, map[string]string{
+		"functions/":        "",
+		"functions/test.js": "exports = function(){ return {b: 1, a: 2}; };",
+		"realm_config.json": `{
+    "config_version": 20210101,
+    "name": "eggcorn",
+    "nested": {
+        "a": "<&>",
+        "z": 1.50
+    }
+}
+`,
+		"invalid.json": `{"name":`,
+	}, files)
+}
+
+func TestAddExportSecretStubs(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range []struct {
+		name     string
+		contents string
+	}{
+		{"realm_config.json", `{"name":"eggcorn"}`},
+		{"secret_stubs.json", `[{"name":"stale","stub":true}]`},
+	} {
+		f, err := w.Create(file.name)
+		assert.Nil(t, err)
+		_, err = f.Write([]byte(file.contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+
+	body, err := addExportSecretStubs(buf.Bytes(), []Secret{
+		{ID: "secret1", Name: "twilio_token"},
+		{ID: "secret2", Name: "aws_key"},
+	})
+	assert.Nil(t, err)
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.Nil(t, err)
+
+	files := map[string]string{}
+	for _, file := range zipPkg.File {
+		r, err := file.Open()
+		assert.Nil(t, err)
+		data, err := ioutil.ReadAll(r)
+		assert.Nil(t, err)
+		files[file.Name] = string(data)
+	}
+
+	assert.Equal(t, map[string]string{
+		"realm_config.json": `{"name":"eggcorn"}`,
+		"secret_stubs.json": `[
+    {
+        "name": "twilio_token",
+        "stub": true
+    },
+    {
+        "name": "aws_key",
+        "stub": true
+    }
+]`,
+	}, files)
+}
+
+func TestEachExportFile(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{"realm_config.json", "functions/", "functions/test.js"} {
+		f, err := w.Create(name)
+		assert.Nil(t, err)
+		if !strings.HasSuffix(name, "/") {
+			_, err = f.Write([]byte("contents of " + name))
+			assert.Nil(t, err)
+		}
+	}
+	assert.Nil(t, w.Close())
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	t.Run("should call the function with each file", func(t *testing.T) {
+		var names []string
+		files := map[string]string{}
+
+		assert.Nil(t, eachExportFile(zipPkg, func(name string, r io.Reader) error {
+			data, err := ioutil.ReadAll(r)
+			assert.Nil(t, err)
+
+			names = append(names, name)
+			files[name] = string(data)
+			return nil
+		}))
+
+		assert.Equal(t, []string{"realm_config.json", "functions/test.js"}, names)
+		assert.Equal(t, map[string]string{
+			"functions/test.js": "contents of functions/test.js",
+			"realm_config.json": "contents of realm_config.json",
+		}, files)
+	})
+
+	t.Run("should stop with the error of the function", func(t *testing.T) {
+		var calls int
+		err := eachExportFile(zipPkg, func(name string, r io.Reader) error {
+			calls++
+			return errors.New("something bad happened")
+		})
+		assert.Equal(t, errors.New("something bad happened"), err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestReadExportFiles(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{"realm_config.json", "functions/", "functions/test.js"} {
+		f, err := w.Create(name)
+		assert.Nil(t, err)
+		if !strings.HasSuffix(name, "/") {
+			_, err = f.Write([]byte("contents of " + name))
+			assert.Nil(t, err)
+		}
+	}
+	assert.Nil(t, w.Close())
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	files, err := readExportFiles(zipPkg)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{
+		"functions/test.js": []byte("contents of functions/test.js"),
+		"realm_config.json": []byte("contents of realm_config.json"),
+	}, files)
+}
+
+func TestWriteExportTarGz(t *testing.T) {
+	newZip := func(names ...string) *zip.Reader {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, name := range names {
+			f, err := w.Create(name)
+			assert.Nil(t, err)
+			if !strings.HasSuffix(name, "/") {
+				_, err = f.Write([]byte("contents of " + name))
+				assert.Nil(t, err)
+			}
+		}
+		assert.Nil(t, w.Close())
+
+		zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.Nil(t, err)
+		return zipPkg
+	}
+
+	t.Run("should repack the export sorted by path", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Nil(t, writeExportTarGz(&buf, newZip("realm_config.json", "functions/", "functions/test.js")))
+
+		gr, err := gzip.NewReader(&buf)
+		assert.Nil(t, err)
+		tr := tar.NewReader(gr)
+
+		var names []string
+		files := map[string]string{}
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, exportTarModTime, header.ModTime.UTC())
+
+			names = append(names, header.Name)
+			if header.Typeflag == tar.TypeReg {
+				data, err := ioutil.ReadAll(tr)
+				assert.Nil(t, err)
+				files[header.Name] = string(data)
+			}
+		}
+
+		assert.Equal(t, []string{"functions/", "functions/test.js", "realm_config.json"}, names)
+		assert.Equal(t, map[string]string{
+			"functions/test.js": "contents of functions/test.js",
+			"realm_config.json": "contents of realm_config.json",
+		}, files)
+	})
+
+	t.Run("should produce the same tarball regardless of the export order", func(t *testing.T) {
+		var first, second bytes.Buffer
+		assert.Nil(t, writeExportTarGz(&first, newZip("a.json", "b.json")))
+		assert.Nil(t, writeExportTarGz(&second, newZip("b.json", "a.json")))
+		assert.Equal(t, first.Bytes(), second.Bytes())
+	})
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	data := []byte(`{"name":"eggcorn","last_modified":1617312000,"functions":[{"name":"test","last_modified":1617312000,"private":false}]}`)
+
+	t.Run("should keep all fields when none are omitted", func(t *testing.T) {
+		assert.Equal(t, `{
+    "functions": [
+        {
+            "last_modified": 1617312000,
+            "name": "test",
+            "private": false
+        }
+    ],
+    "last_modified": 1617312000,
+    "name": "eggcorn"
+}
+`, string(canonicalJSON(data, exportJSONOptions{})))
+	})
+
+	t.Run("should omit the fields at the top level only", func(t *testing.T) {
+		assert.Equal(t, `{
+    "functions": [
+        {
+            "last_modified": 1617312000,
+            "name": "test",
+            "private": false
+        }
+    ],
+    "name": "eggcorn"
+}
+`, string(canonicalJSON(data, exportJSONOptions{omitFields: DefaultExportVolatileFields})))
+	})
+
+	t.Run("should omit the fields from each entry of a list", func(t *testing.T) {
+		data := []byte(`[{"path":"/index.html","last_modified":1617312000},{"path":"/app.js","last_modified":1617312000}]`)
+
+		assert.Equal(t, `[
+    {
+        "path": "/index.html"
+    },
+    {
+        "path": "/app.js"
+    }
+]
+`, string(canonicalJSON(data, exportJSONOptions{omitFields: DefaultExportVolatileFields})))
+	})
+
+	t.Run("should keep a schema property named like a volatile field", func(t *testing.T) {
+		data := []byte(`{"title":"item","properties":{"_id":{"bsonType":"objectId"},"updated_at":{"bsonType":"date"}}}`)
+
+		assert.Equal(t, `{
+    "properties": {
+        "_id": {
+            "bsonType": "objectId"
+        },
+        "updated_at": {
+            "bsonType": "date"
+        }
+    },
+    "title": "item"
+}
+`, string(canonicalJSON(data, exportJSONOptions{omitFields: DefaultExportVolatileFields})))
+	})
+}
+
+func TestExportBytesContext(t *testing.T) {
+	t.Run("should fail once the export request context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		c := NewClientWithOptions("http://localhost:8081", nil, ClientOptions{
+			CredentialStore: &testCredentialStore{session: user.Session{AccessToken: "access-token"}},
+		})
+
+		_, _, err := c.ExportBytes("groupID", "appID", ExportRequest{Context: ctx})
+		assert.True(t, errors.Is(err, context.Canceled), "expected error to be context canceled but got: %v", err)
+	})
+}
@@ -12,6 +12,8 @@ import (
 const (
 	FunctionsPattern               = appPathPattern + "/functions"
 	AppDebugExecuteFunctionPattern = appPathPattern + "/debug/execute_function"
+
+	functionPathPattern = FunctionsPattern + "/%s"
 )
 
 type stats struct {
@@ -28,8 +30,9 @@ type ExecutionResults struct {
 
 // Function is a realm Function
 type Function struct {
-	ID   string `json:"_id"`
-	Name string `json:"name"`
+	ID     string `json:"_id"`
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
 }
 
 func (c *client) AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error) {
@@ -83,3 +86,75 @@ func (c *client) Functions(groupID, appID string) ([]Function, error) {
 	}
 	return result, nil
 }
+
+func (c *client) ExportFunction(groupID, appID, functionName string) (string, error) {
+	function, err := c.findFunctionByName(groupID, appID, functionName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.getFunction(groupID, appID, function.ID, &function); err != nil {
+		return "", err
+	}
+	return function.Source, nil
+}
+
+func (c *client) UpdateFunction(groupID, appID, functionName, source string) error {
+	function, err := c.findFunctionByName(groupID, appID, functionName)
+	if err != nil {
+		return err
+	}
+
+	// the function is updated as a whole, so its full configuration is
+	// retrieved first to ensure only the source changes
+	var config map[string]interface{}
+	if err := c.getFunction(groupID, appID, function.ID, &config); err != nil {
+		return err
+	}
+	config["source"] = source
+
+	res, resErr := c.doJSON(
+		http.MethodPut,
+		fmt.Sprintf(functionPathPattern, groupID, appID, function.ID),
+		config,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update function", res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) getFunction(groupID, appID, functionID string, function interface{}) error {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(functionPathPattern, groupID, appID, functionID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return api.ErrUnexpectedStatusCode{"get function", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(function)
+}
+
+func (c *client) findFunctionByName(groupID, appID, functionName string) (Function, error) {
+	functions, err := c.Functions(groupID, appID)
+	if err != nil {
+		return Function{}, err
+	}
+
+	for _, function := range functions {
+		if function.Name == functionName {
+			return function, nil
+		}
+	}
+	return Function{}, ErrFunctionNotFound{functionName}
+}
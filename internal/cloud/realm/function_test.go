@@ -63,6 +63,109 @@ func TestFunctions(t *testing.T) {
 	})
 }
 
+func TestExportFunction(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.ExportFunction(u.CloudGroupID(), "test-app-1234", "test")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an app containing a function", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "export-function-test")
+		defer teardown()
+
+		source := "exports = function(){\n  return \"successful test\";\n};"
+
+		assert.Nil(t, client.Import(groupID, app.ID, local.AppDataV2{local.AppStructureV2{
+			ConfigVersion:   realm.AppConfigVersion20210101,
+			ID:              app.ClientAppID,
+			Name:            app.Name,
+			Location:        app.Location,
+			DeploymentModel: app.DeploymentModel,
+			Functions: local.FunctionsStructure{
+				Configs: []map[string]interface{}{
+					{"name": "test", "private": true},
+				},
+				Sources: map[string]string{"test.js": source},
+			},
+		}}))
+
+		t.Run("should export the function source", func(t *testing.T) {
+			actual, err := client.ExportFunction(groupID, app.ID, "test")
+			assert.Nil(t, err)
+			assert.Equal(t, source, actual)
+		})
+
+		t.Run("should fail to export a function that does not exist", func(t *testing.T) {
+			_, err := client.ExportFunction(groupID, app.ID, "missing")
+			assert.Equal(t, realm.ErrFunctionNotFound{"missing"}, err)
+		})
+	})
+}
+
+func TestUpdateFunction(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		err := client.UpdateFunction(u.CloudGroupID(), "test-app-1234", "test", "exports = function(){};")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an app containing a function", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "update-function-test")
+		defer teardown()
+
+		assert.Nil(t, client.Import(groupID, app.ID, local.AppDataV2{local.AppStructureV2{
+			ConfigVersion:   realm.AppConfigVersion20210101,
+			ID:              app.ClientAppID,
+			Name:            app.Name,
+			Location:        app.Location,
+			DeploymentModel: app.DeploymentModel,
+			Functions: local.FunctionsStructure{
+				Configs: []map[string]interface{}{
+					{"name": "test", "private": true},
+				},
+				Sources: map[string]string{
+					"test.js": "exports = function(){\n  return \"successful test\";\n};",
+				},
+			},
+		}}))
+
+		t.Run("should update the function source", func(t *testing.T) {
+			source := "exports = function(){\n  return \"updated test\";\n};"
+
+			assert.Nil(t, client.UpdateFunction(groupID, app.ID, "test", source))
+
+			actual, err := client.ExportFunction(groupID, app.ID, "test")
+			assert.Nil(t, err)
+			assert.Equal(t, source, actual)
+		})
+
+		t.Run("should fail to update a function with invalid source", func(t *testing.T) {
+			err := client.UpdateFunction(groupID, app.ID, "test", "exports = function(){")
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should fail to update a function that does not exist", func(t *testing.T) {
+			err := client.UpdateFunction(groupID, app.ID, "missing", "exports = function(){};")
+			assert.Equal(t, realm.ErrFunctionNotFound{"missing"}, err)
+		})
+	})
+}
+
 func TestAppDebugExecuteFunction(t *testing.T) {
 	u.SkipUnlessRealmServerRunning(t)
 
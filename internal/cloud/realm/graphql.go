@@ -0,0 +1,40 @@
+package realm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	graphQLSchemaPathPattern = appPathPattern + "/graphql/schema"
+)
+
+func (c *client) GraphQLSchema(groupID, appID string) (string, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(graphQLSchemaPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return "", resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", api.ErrUnexpectedStatusCode{"get graphql schema", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	body, bodyErr := ioutil.ReadAll(res.Body)
+	if bodyErr != nil {
+		return "", bodyErr
+	}
+
+	sdl := string(body)
+	if strings.TrimSpace(sdl) == "" {
+		return "", ErrGraphQLNotEnabled
+	}
+	return sdl, nil
+}
@@ -0,0 +1,33 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmGraphQLSchema(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.GraphQLSchema(u.CloudGroupID(), "test-app-1234")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "graphql-test")
+		defer teardown()
+
+		t.Run("should fail to get the graphql schema with no app schema", func(t *testing.T) {
+			_, err := client.GraphQLSchema(groupID, app.ID)
+			assert.Equal(t, realm.ErrGraphQLNotEnabled, err)
+		})
+	})
+}
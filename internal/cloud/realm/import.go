@@ -1,24 +1,94 @@
 package realm
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
+
+	"gopkg.in/yaml.v2"
 )
 
 const (
 	importPathPattern = appPathPattern + "/import"
 
-	importQueryDiff     = "diff"
-	importQueryStrategy = "strategy"
+	importQueryDeploymentID = "deployment_id"
+	importQueryDiff         = "diff"
+	importQueryStrategy     = "strategy"
+)
 
-	importStrategyReplaceByName = "replace-by-name"
+// set of supported import strategies
+const (
+	ImportStrategyMerge         = "merge"
+	ImportStrategyReplace       = "replace"
+	ImportStrategyReplaceByName = "replace-by-name"
 )
 
+// ImportOptions are options to configure a Realm app import
+type ImportOptions struct {
+	// Strategy is the strategy used to apply the app data, defaults to ImportStrategyReplaceByName
+	Strategy string
+
+	// Timeout is the maximum duration to wait for the import to complete, once exceeded
+	// the import fails with ErrWaitTimeout; the server has no way to cancel an import,
+	// so it may still have been partially or fully applied
+	Timeout time.Duration
+
+	// PlaceholderValues are the values substituted for the placeholders found in
+	// the app data (e.g. "{{app_id}}" as produced by ExportRequest.UsePlaceholders),
+	// keyed by placeholder name; when set, the import fails if any placeholder is left
+	// without a value, otherwise the app data is imported as is
+	PlaceholderValues map[string]string
+
+	// Variables are the values substituted for the variables referenced in the app data's
+	// string values (e.g. "${CLUSTER_NAME}"), keyed by variable name, which lets a single
+	// config serve every environment; see SubstituteVariables
+	Variables map[string]string
+
+	// Checkpoint receives the id of each app successfully imported by ImportMany,
+	// written as newline-delimited app ids as soon as each import completes, so that
+	// a restarted batch can skip them (see ReadImportCheckpoint)
+	Checkpoint io.Writer
+
+	// Skip reports whether ImportMany should skip importing the app with the provided id,
+	// skipped apps are counted as succeeded
+	Skip func(appID string) bool
+
+	// Query holds extra query parameters to include with the import request,
+	// which allows adopting new server features ahead of first-class support;
+	// parameters set through the other import options take precedence
+	Query map[string]string
+}
+
 func (c *client) Diff(groupID, appID string, appData interface{}) ([]string, error) {
-	res, resErr := c.doImport(groupID, appID, appData, true)
+	return c.DiffDeployment(groupID, appID, "", appData)
+}
+
+func (c *client) DiffDeployment(groupID, appID, deploymentID string, appData interface{}) ([]string, error) {
+	var opts ImportOptions
+	if deploymentID != "" {
+		opts.Query = map[string]string{importQueryDeploymentID: deploymentID}
+	}
+
+	return c.diff(groupID, appID, appData, opts)
+}
+
+func (c *client) DiffWithWarnings(groupID, appID string, appData interface{}) (DiffResult, error) {
+	diffs, err := c.diff(groupID, appID, appData, ImportOptions{})
+	if err != nil {
+		return DiffResult{}, err
+	}
+	return newDiffResult(diffs), nil
+}
+
+func (c *client) diff(groupID, appID string, appData interface{}, opts ImportOptions) ([]string, error) {
+	res, resErr := c.doImport(context.Background(), groupID, appID, appData, opts, true)
 	if resErr != nil {
 		return nil, resErr
 	}
@@ -34,9 +104,78 @@ func (c *client) Diff(groupID, appID string, appData interface{}) ([]string, err
 	return diffs, nil
 }
 
+func (c *client) DiffGrouped(groupID, appID string, appData interface{}) (map[string][]string, error) {
+	diffs, err := c.diff(groupID, appID, appData, ImportOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return newDiffResult(diffs).Grouped(), nil
+}
+
+func (c *client) DestructiveChanges(groupID, appID string, appData []byte, strategy string) ([]string, error) {
+	diffs, err := c.diff(groupID, appID, json.RawMessage(appData), ImportOptions{Strategy: strategy})
+	if err != nil {
+		return nil, err
+	}
+	return newDiffResult(diffs).Removals(), nil
+}
+
+func (c *client) ImportMany(groupID string, appData map[string]interface{}, opts ImportOptions) error {
+	errs := map[string]error{}
+	for appID, data := range appData {
+		if opts.Skip != nil && opts.Skip(appID) {
+			continue
+		}
+		if err := c.ImportWithOptions(groupID, appID, data, opts); err != nil {
+			errs[appID] = err
+			continue
+		}
+		if opts.Checkpoint != nil {
+			if _, err := fmt.Fprintln(opts.Checkpoint, appID); err != nil {
+				return fmt.Errorf("failed to write import checkpoint: %w", err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return BatchError{errs, len(appData) - len(errs)}
+	}
+	return nil
+}
+
+// ReadImportCheckpoint reads the ids of the apps recorded in an ImportMany checkpoint,
+// which can be used to skip them when the batch is restarted
+func ReadImportCheckpoint(r io.Reader) (map[string]bool, error) {
+	appIDs := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if appID := strings.TrimSpace(scanner.Text()); appID != "" {
+			appIDs[appID] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read import checkpoint: %w", err)
+	}
+	return appIDs, nil
+}
+
 func (c *client) Import(groupID, appID string, appData interface{}) error {
-	res, resErr := c.doImport(groupID, appID, appData, false)
+	return c.ImportWithOptions(groupID, appID, appData, ImportOptions{})
+}
+
+func (c *client) ImportWithOptions(groupID, appID string, appData interface{}, opts ImportOptions) error {
+	ctx := context.Background()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	res, resErr := c.doImport(ctx, groupID, appID, appData, opts, false)
 	if resErr != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrWaitTimeout{opts.Timeout}
+		}
 		return resErr
 	}
 	if res.StatusCode != http.StatusNoContent {
@@ -45,16 +184,110 @@ func (c *client) Import(groupID, appID string, appData interface{}) error {
 	return nil
 }
 
-func (c *client) doImport(groupID, appID string, appData interface{}, diff bool) (*http.Response, error) {
-	query := map[string]string{importQueryStrategy: importStrategyReplaceByName}
+func (c *client) ImportAndVerify(groupID, appID string, appData interface{}, opts ImportOptions) error {
+	if err := c.ImportWithOptions(groupID, appID, appData, opts); err != nil {
+		return err
+	}
+
+	diffs, err := c.diff(groupID, appID, appData, ImportOptions{
+		Strategy:          opts.Strategy,
+		Variables:         opts.Variables,
+		PlaceholderValues: opts.PlaceholderValues,
+		Query:             opts.Query,
+	})
+	if err != nil {
+		return err
+	}
+	if result := newDiffResult(diffs); result.HasChanges() {
+		return ErrImportNotConverged{result.Changes}
+	}
+	return nil
+}
+
+func (c *client) doImport(ctx context.Context, groupID, appID string, appData interface{}, opts ImportOptions, diff bool) (*http.Response, error) {
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = ImportStrategyReplaceByName
+	}
+
+	query := map[string]string{importQueryStrategy: strategy}
 	if diff {
 		query[importQueryDiff] = trueVal
 	}
 
+	if err := includeExtraQuery(query, opts.Query); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Variables) > 0 {
+		data, err := SubstituteVariables(appData, opts.Variables)
+		if err != nil {
+			return nil, err
+		}
+		appData = data
+	}
+
+	if len(opts.PlaceholderValues) > 0 {
+		data, err := substitutePlaceholders(appData, opts.PlaceholderValues)
+		if err != nil {
+			return nil, err
+		}
+		appData = data
+	}
+
 	return c.doJSON(
 		http.MethodPost,
 		fmt.Sprintf(importPathPattern, groupID, appID),
 		appData,
-		api.RequestOptions{Query: query},
+		api.RequestOptions{Context: ctx, Query: query},
 	)
 }
+
+func (c *client) ImportYAML(groupID, appID string, data []byte, opts ImportOptions) error {
+	appData, err := parseYAMLAppData(data)
+	if err != nil {
+		return err
+	}
+	return c.ImportWithOptions(groupID, appID, appData, opts)
+}
+
+// parseYAMLAppData parses the yaml app data into its structurally equivalent json representation
+func parseYAMLAppData(data []byte) (interface{}, error) {
+	var appData interface{}
+	if err := yaml.Unmarshal(data, &appData); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml app data: %w", err)
+	}
+	return normalizeYAML(appData)
+}
+
+// normalizeYAML converts the yaml maps, which allow for keys of any type,
+// into the equivalent json objects, which only allow for string keys
+func normalizeYAML(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(value))
+		for key, val := range value {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("failed to parse yaml app data: key %v is not a string", key)
+			}
+			normalized, err := normalizeYAML(val)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = normalized
+		}
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(value))
+		for i, val := range value {
+			normalized, err := normalizeYAML(val)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = normalized
+		}
+		return arr, nil
+	}
+	return v, nil
+}
@@ -2,11 +2,13 @@ package realm_test
 
 import (
 	"archive/zip"
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 	"github.com/10gen/realm-cli/internal/local"
@@ -206,6 +208,45 @@ func TestRealmImportLegacy(t *testing.T) {
 	}
 }
 
+func TestRealmImportWithOptions(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	client := newAuthClient(t)
+
+	groupID := u.CloudGroupID()
+
+	app, teardown := setupTestApp(t, client, groupID, "import-with-options")
+	defer teardown()
+
+	t.Run("Should import with a merge strategy successfully", func(t *testing.T) {
+		assert.Nil(t, client.ImportWithOptions(groupID, app.ID, appDataV2(app), realm.ImportOptions{
+			Strategy: realm.ImportStrategyMerge,
+		}))
+	})
+
+	t.Run("Should fail to import once the timeout is exceeded", func(t *testing.T) {
+		err := client.ImportWithOptions(groupID, app.ID, appDataV2(app), realm.ImportOptions{
+			Timeout: time.Nanosecond,
+		})
+		assert.Equal(t, realm.ErrWaitTimeout{time.Nanosecond}, err)
+	})
+
+	t.Run("Should import and verify the app converged with a replace strategy", func(t *testing.T) {
+		assert.Nil(t, client.ImportAndVerify(groupID, app.ID, appDataV2(app), realm.ImportOptions{
+			Strategy: realm.ImportStrategyReplace,
+		}))
+	})
+
+	t.Run("Should record the imported apps in the checkpoint", func(t *testing.T) {
+		var checkpoint bytes.Buffer
+
+		assert.Nil(t, client.ImportMany(groupID, map[string]interface{}{app.ID: appDataV2(app)}, realm.ImportOptions{
+			Checkpoint: &checkpoint,
+		}))
+		assert.Equal(t, app.ID+"\n", checkpoint.String())
+	})
+}
+
 func appDataV1(configVersion realm.AppConfigVersion, app realm.App) local.AppDataV1 {
 	return local.AppDataV1{local.AppStructureV1{
 		ConfigVersion:        configVersion,
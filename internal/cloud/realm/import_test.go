@@ -0,0 +1,122 @@
+package realm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestParseYAMLAppData(t *testing.T) {
+	t.Run("should parse yaml app data into its json representation", func(t *testing.T) {
+		appData, err := parseYAMLAppData([]byte(`
+# the app config
+config_version: 20210101
+name: eggcorn
+functions:
+  - name: test
+    private: true
+`))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"config_version": 20210101,
+			"name":           "eggcorn",
+			"functions": []interface{}{
+				map[string]interface{}{"name": "test", "private": true},
+			},
+		}, appData)
+	})
+
+	t.Run("should fail to parse invalid yaml", func(t *testing.T) {
+		_, err := parseYAMLAppData([]byte("name: [eggcorn"))
+		assert.Equal(t, "failed to parse yaml app data: yaml: line 1: did not find expected ',' or ']'", err.Error())
+	})
+
+	t.Run("should fail to parse yaml with keys that are not strings", func(t *testing.T) {
+		_, err := parseYAMLAppData([]byte("values:\n  1: one"))
+		assert.Equal(t, errors.New("failed to parse yaml app data: key 1 is not a string"), err)
+	})
+}
+
+func TestReadImportCheckpoint(t *testing.T) {
+	t.Run("should read the app ids of a checkpoint", func(t *testing.T) {
+		appIDs, err := ReadImportCheckpoint(strings.NewReader("app1\n\n  app2  \napp3"))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]bool{"app1": true, "app2": true, "app3": true}, appIDs)
+	})
+
+	t.Run("should read an empty checkpoint", func(t *testing.T) {
+		appIDs, err := ReadImportCheckpoint(strings.NewReader(""))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]bool{}, appIDs)
+	})
+}
+
+func TestImportManySkip(t *testing.T) {
+	t.Run("should skip the apps already recorded in the checkpoint", func(t *testing.T) {
+		completed, err := ReadImportCheckpoint(strings.NewReader("app1\napp2\n"))
+		assert.Nil(t, err)
+
+		var checkpoint bytes.Buffer
+
+		c := NewClient("http://localhost:8081")
+
+		err = c.ImportMany("groupID", map[string]interface{}{"app1": nil, "app2": nil}, ImportOptions{
+			Checkpoint: &checkpoint,
+			Skip:       func(appID string) bool { return completed[appID] },
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "", checkpoint.String())
+	})
+}
+
+func TestImportTimeout(t *testing.T) {
+	var requests []string
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	err := client.ImportWithOptions("groupID", "appID", map[string]interface{}{"name": "eggcorn"}, ImportOptions{
+		Timeout: 10 * time.Millisecond,
+	})
+	assert.Equal(t, ErrWaitTimeout{10 * time.Millisecond}, err)
+
+	var timeoutErr ErrWaitTimeout
+	assert.True(t, errors.As(err, &timeoutErr), "expected a wait timeout error")
+	assert.Equal(t, []string{"POST /api/admin/v3.0/groups/groupID/apps/appID/import"}, requests)
+}
+
+func TestDiff(t *testing.T) {
+	serverDiffs := []string{"warning: something to note", "--- functions/test.js\n+++ functions/test.js"}
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		body, err := json.Marshal(serverDiffs)
+		if err != nil {
+			return nil, err
+		}
+		return testResponse(req, http.StatusOK, string(body)), nil
+	})
+
+	t.Run("should return the diffs in the order the server reports them", func(t *testing.T) {
+		diffs, err := client.Diff("groupID", "appID", map[string]interface{}{"name": "eggcorn"})
+		assert.Nil(t, err)
+		assert.Equal(t, serverDiffs, diffs)
+	})
+
+	t.Run("should separate the warnings from the changes", func(t *testing.T) {
+		result, err := client.DiffWithWarnings("groupID", "appID", map[string]interface{}{"name": "eggcorn"})
+		assert.Nil(t, err)
+		assert.Equal(t, DiffResult{
+			Changes:  []string{"--- functions/test.js\n+++ functions/test.js"},
+			Warnings: []string{"warning: something to note"},
+		}, result)
+	})
+}
@@ -0,0 +1,115 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	logForwardersPathPattern = appPathPattern + "/log_forwarders"
+	logForwarderPathPattern  = logForwardersPathPattern + "/%s"
+)
+
+// set of supported log forwarder action types
+const (
+	LogForwarderActionTypeCollection = "collection"
+	LogForwarderActionTypeFunction   = "function"
+)
+
+// set of supported log forwarder policy types
+const (
+	LogForwarderPolicyTypeSingle = "single"
+	LogForwarderPolicyTypeBatch  = "batch"
+)
+
+// LogForwarder is a Realm app log forwarder, which forwards the app's logs to an external sink
+type LogForwarder struct {
+	ID          string             `json:"_id,omitempty"`
+	Name        string             `json:"name"`
+	Disabled    bool               `json:"disabled"`
+	LogTypes    []string           `json:"log_types"`
+	LogStatuses []string           `json:"log_statuses"`
+	Policy      LogForwarderPolicy `json:"policy"`
+	Action      LogForwarderAction `json:"action"`
+}
+
+// LogForwarderPolicy is how a log forwarder groups the logs it forwards
+type LogForwarderPolicy struct {
+	Type string `json:"type"`
+}
+
+// LogForwarderAction is the destination a log forwarder forwards logs to,
+// which is either a linked data source collection or a function
+type LogForwarderAction struct {
+	Type       string `json:"type"`
+	Name       string `json:"name,omitempty"`
+	DataSource string `json:"data_source,omitempty"`
+	Database   string `json:"database,omitempty"`
+	Collection string `json:"collection,omitempty"`
+}
+
+func (c *client) LogForwarders(groupID, appID string) ([]LogForwarder, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(logForwardersPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get log forwarders", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var forwarders []LogForwarder
+	if err := json.NewDecoder(res.Body).Decode(&forwarders); err != nil {
+		return nil, err
+	}
+	return forwarders, nil
+}
+
+func (c *client) UpsertLogForwarder(groupID, appID string, forwarder LogForwarder) (LogForwarder, error) {
+	if forwarder.ID != "" {
+		return forwarder, c.updateLogForwarder(groupID, appID, forwarder)
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(logForwardersPathPattern, groupID, appID),
+		forwarder,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return LogForwarder{}, resErr
+	}
+	if res.StatusCode != http.StatusCreated {
+		return LogForwarder{}, api.ErrUnexpectedStatusCode{"create log forwarder", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var created LogForwarder
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return LogForwarder{}, err
+	}
+	return created, nil
+}
+
+func (c *client) updateLogForwarder(groupID, appID string, forwarder LogForwarder) error {
+	res, resErr := c.doJSON(
+		http.MethodPut,
+		fmt.Sprintf(logForwarderPathPattern, groupID, appID, forwarder.ID),
+		forwarder,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update log forwarder", res.StatusCode}
+	}
+	return nil
+}
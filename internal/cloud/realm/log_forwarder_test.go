@@ -0,0 +1,46 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmLogForwarders(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.LogForwarders(u.CloudGroupID(), "test-app-1234")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "log-forwarders-test")
+		defer teardown()
+
+		t.Run("should have no log forwarders upon app initialization", func(t *testing.T) {
+			forwarders, err := client.LogForwarders(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(forwarders))
+		})
+
+		t.Run("should fail to create a log forwarder with a missing function", func(t *testing.T) {
+			_, err := client.UpsertLogForwarder(groupID, app.ID, realm.LogForwarder{
+				Name:        "siem",
+				LogTypes:    []string{"auth"},
+				LogStatuses: []string{"error"},
+				Policy:      realm.LogForwarderPolicy{Type: realm.LogForwarderPolicyTypeSingle},
+				Action:      realm.LogForwarderAction{Type: realm.LogForwarderActionTypeFunction, Name: "missing"},
+			})
+			assert.NotNil(t, err)
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,8 +14,10 @@ import (
 const (
 	logsPathPattern = appPathPattern + "/logs"
 
+	logsQueryCoID       = "co_id"
 	logsQueryEndDate    = "end_date"
 	logsQueryErrorsOnly = "errors_only"
+	logsQuerySkip       = "skip"
 	logsQueryStartDate  = "start_date"
 	logsQueryType       = "type"
 
@@ -54,6 +57,10 @@ type LogsOptions struct {
 	Types      []string
 	Start      time.Time
 	End        time.Time
+
+	// RequestID filters the logs down to those of the request with the provided
+	// correlation id, as reported by the request's log entries
+	RequestID string
 }
 
 // Logs is an array of Realm app logs
@@ -66,6 +73,7 @@ func (l Logs) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 // Log is a Realm app log
 type Log struct {
 	Messages              []interface{} `json:"messages"`
+	RequestID             string        `json:"co_id"`
 	Type                  string        `json:"type"`
 	Started               time.Time     `json:"started"`
 	Completed             time.Time     `json:"completed"`
@@ -88,11 +96,35 @@ type LogAuthEvent struct {
 	Provider string `json:"provider"`
 }
 
+// LogsCursor points to the next page of a Realm app's logs, logs are paged
+// from the most recent ones backwards.
+//
+// A cursor is returned alongside each page of logs by LogsPage and is passed back
+// with the same LogsOptions to fetch the following page; it is only meaningful for
+// the options it was returned with. Once the last page has been returned the cursor
+// is zero, so paging stops whenever the caller decides to or when IsZero reports true.
+type LogsCursor struct {
+	EndDate time.Time
+	Skip    int
+}
+
+// IsZero reports whether the cursor is empty, meaning there are no more pages of logs
+func (cursor LogsCursor) IsZero() bool {
+	return cursor.EndDate.IsZero() && cursor.Skip == 0
+}
+
 type logsResponse struct {
-	Logs []Log `json:"logs"`
+	Logs        []Log      `json:"logs"`
+	NextEndDate *time.Time `json:"nextEndDate"`
+	NextSkip    int        `json:"nextSkip"`
 }
 
 func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
+	logs, _, err := c.LogsPage(groupID, appID, opts, LogsCursor{})
+	return logs, err
+}
+
+func (c *client) LogsPage(groupID, appID string, opts LogsOptions, cursor LogsCursor) (Logs, LogsCursor, error) {
 	query := map[string]string{}
 	if len(opts.Types) > 0 {
 		query[logsQueryType] = strings.Join(opts.Types, ",")
@@ -106,6 +138,15 @@ func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
 	if !opts.End.IsZero() {
 		query[logsQueryEndDate] = opts.End.Format(logsDateFormat)
 	}
+	if opts.RequestID != "" {
+		query[logsQueryCoID] = opts.RequestID
+	}
+	if !cursor.EndDate.IsZero() {
+		query[logsQueryEndDate] = cursor.EndDate.Format(logsDateFormat)
+	}
+	if cursor.Skip > 0 {
+		query[logsQuerySkip] = strconv.Itoa(cursor.Skip)
+	}
 
 	res, err := c.do(
 		http.MethodGet,
@@ -113,16 +154,21 @@ func (c *client) Logs(groupID, appID string, opts LogsOptions) (Logs, error) {
 		api.RequestOptions{Query: query},
 	)
 	if err != nil {
-		return nil, err
+		return nil, LogsCursor{}, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, api.ErrUnexpectedStatusCode{"get logs", res.StatusCode}
+		return nil, LogsCursor{}, api.ErrUnexpectedStatusCode{"get logs", res.StatusCode}
 	}
 	defer res.Body.Close()
 
 	var out logsResponse
 	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, err
+		return nil, LogsCursor{}, err
+	}
+
+	var next LogsCursor
+	if out.NextEndDate != nil {
+		next = LogsCursor{EndDate: *out.NextEndDate, Skip: out.NextSkip}
 	}
-	return out.Logs, nil
+	return out.Logs, next, nil
 }
@@ -23,5 +23,18 @@ func TestRealmLogs(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, 0, len(logs))
 		})
+
+		t.Run("getting a page of logs should return an empty cursor if there are no more", func(t *testing.T) {
+			logs, next, err := client.LogsPage(groupID, app.ID, realm.LogsOptions{}, realm.LogsCursor{})
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(logs))
+			assert.True(t, next.IsZero(), "expected the cursor to be empty")
+		})
+
+		t.Run("getting logs for a request should return an empty list if there are none", func(t *testing.T) {
+			logs, err := client.Logs(groupID, app.ID, realm.LogsOptions{RequestID: "60b0f3a1c2d4e5f6a7b8c9d0"})
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(logs))
+		})
 	})
 }
@@ -0,0 +1,39 @@
+package realm
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	operationLabelHeader = "X-BAAS-Operation-Label"
+)
+
+type operationLabelKey struct{}
+
+// WithOperationLabel returns a copy of the context labeled with the operation its requests
+// are made for (e.g. "nightly-deploy"), which takes precedence over the client's label
+func WithOperationLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, operationLabelKey{}, label)
+}
+
+// OperationLabel returns the operation label of the context, if any, which allows transport
+// middlewares to include the label of each request they see in their logs or metrics
+func OperationLabel(ctx context.Context) string {
+	label, _ := ctx.Value(operationLabelKey{}).(string)
+	return label
+}
+
+// labelOperation sends the request's operation label as a header, the label is also set
+// on the request's context when it only comes from the client so that it can always be read from there
+func (c *client) labelOperation(req *http.Request) *http.Request {
+	label := OperationLabel(req.Context())
+	if label == "" && c.options.OperationLabel != "" {
+		label = c.options.OperationLabel
+		req = req.WithContext(WithOperationLabel(req.Context(), label))
+	}
+	if label != "" {
+		req.Header.Set(operationLabelHeader, label)
+	}
+	return req
+}
@@ -0,0 +1,74 @@
+package realm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientOperationLabel(t *testing.T) {
+	type sentLabel struct {
+		Header  string
+		Context string
+	}
+
+	newLabelClient := func(label string, sent *[]sentLabel) Client {
+		return newTestClientWithOptions("http://localhost:8081", ClientOptions{
+			OperationLabel: label,
+			Retry:          RetryOptions{MaxAttempts: 2, BaseDelay: time.Millisecond},
+		}, func(req *http.Request) (*http.Response, error) {
+			*sent = append(*sent, sentLabel{req.Header.Get(operationLabelHeader), OperationLabel(req.Context())})
+
+			status := http.StatusOK
+			if len(*sent) == 1 {
+				status = http.StatusServiceUnavailable
+			}
+			return testResponse(req, status, ""), nil
+		})
+	}
+
+	for _, tc := range []struct {
+		description   string
+		clientLabel   string
+		contextLabel  string
+		expectedLabel string
+	}{
+		{
+			description: "should not label requests without a label",
+		},
+		{
+			description:   "should label every attempt with the client's label",
+			clientLabel:   "nightly-deploy",
+			expectedLabel: "nightly-deploy",
+		},
+		{
+			description:   "should label every attempt with the context's label over the client's label",
+			clientLabel:   "nightly-deploy",
+			contextLabel:  "pr-123",
+			expectedLabel: "pr-123",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var sent []sentLabel
+			client := newLabelClient(tc.clientLabel, &sent)
+
+			ctx := context.Background()
+			if tc.contextLabel != "" {
+				ctx = WithOperationLabel(ctx, tc.contextLabel)
+			}
+
+			res, err := client.DoRequest(http.MethodGet, "/path", api.RequestOptions{Context: ctx, NoAuth: true, Retry: true})
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, []sentLabel{
+				{tc.expectedLabel, tc.expectedLabel},
+				{tc.expectedLabel, tc.expectedLabel},
+			}, sent)
+		})
+	}
+}
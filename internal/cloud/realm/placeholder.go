@@ -0,0 +1,114 @@
+package realm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// set of placeholder names for the environment specific values of an export
+const (
+	PlaceholderAppID       = "app_id"
+	PlaceholderClusterName = "cluster_name"
+)
+
+// DefaultExportPlaceholderFields are the environment specific fields templated
+// by default when exporting with placeholders, keyed by field name
+// with the name of their placeholder as value: the client app id ("app_id")
+// and the name of the Atlas cluster a data source is linked to ("clusterName");
+// any other environment specific value must be added through ExportRequest.PlaceholderFields
+var DefaultExportPlaceholderFields = map[string]string{
+	"app_id":      PlaceholderAppID,
+	"clusterName": PlaceholderClusterName,
+}
+
+// Placeholder returns the placeholder with the provided name,
+// which takes the form "{{<name>}}" (e.g. "{{app_id}}")
+func Placeholder(name string) string {
+	return "{{" + name + "}}"
+}
+
+// placeholderName returns the name of the placeholder the value is if it is one
+func placeholderName(s string) (string, bool) {
+	if !strings.HasPrefix(s, "{{") || !strings.HasSuffix(s, "}}") || len(s) <= 4 {
+		return "", false
+	}
+	return s[2 : len(s)-2], true
+}
+
+// templateJSONFields replaces the string values of the fields
+// in the decoded json value with their placeholder at any depth
+func templateJSONFields(v interface{}, fields map[string]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range val {
+			if name, ok := fields[key]; ok {
+				if _, isString := elem.(string); isString {
+					val[key] = Placeholder(name)
+					continue
+				}
+			}
+			templateJSONFields(elem, fields)
+		}
+	case []interface{}:
+		for _, elem := range val {
+			templateJSONFields(elem, fields)
+		}
+	}
+}
+
+// substitutePlaceholders returns the json encoded app data with each of its
+// placeholders replaced by the provided values, keyed by placeholder name
+func substitutePlaceholders(appData interface{}, values map[string]string) (json.RawMessage, error) {
+	data, err := json.Marshal(appData)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	substituted, err := substituteJSONPlaceholders(v, values)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(substituted)
+}
+
+func substituteJSONPlaceholders(v interface{}, values map[string]string) (interface{}, error) {
+	switch val := v.(type) {
+	case string:
+		name, ok := placeholderName(val)
+		if !ok {
+			return val, nil
+		}
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("missing value for placeholder: %s", val)
+		}
+		return value, nil
+	case map[string]interface{}:
+		for key, elem := range val {
+			substituted, err := substituteJSONPlaceholders(elem, values)
+			if err != nil {
+				return nil, err
+			}
+			val[key] = substituted
+		}
+	case []interface{}:
+		for i, elem := range val {
+			substituted, err := substituteJSONPlaceholders(elem, values)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = substituted
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+package realm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestCanonicalJSONPlaceholders(t *testing.T) {
+	data := []byte(`{"app_id":"eggcorn-abcde","name":"eggcorn","nested":{"app_id":"eggcorn-abcde"},"ids":{"app_id":1},"config":{"clusterName":"Cluster0"}}`)
+
+	assert.Equal(t, `{
+    "app_id": "{{app_id}}",
+    "config": {
+        "clusterName": "{{cluster_name}}"
+    },
+    "ids": {
+        "app_id": 1
+    },
+    "name": "eggcorn",
+    "nested": {
+        "app_id": "{{app_id}}"
+    }
+}
+`, string(canonicalJSON(data, exportJSONOptions{placeholderFields: DefaultExportPlaceholderFields})))
+}
+
+func TestSubstitutePlaceholders(t *testing.T) {
+	appData := map[string]interface{}{
+		"app_id":         "{{app_id}}",
+		"name":           "eggcorn",
+		"config_version": 20210101,
+		"functions":      []interface{}{map[string]interface{}{"name": "{{not a placeholder"}},
+	}
+
+	t.Run("should substitute the placeholders with their values", func(t *testing.T) {
+		data, err := substitutePlaceholders(appData, map[string]string{PlaceholderAppID: "eggcorn-fghij"})
+		assert.Nil(t, err)
+		assert.Equal(t, json.RawMessage(`{"app_id":"eggcorn-fghij","config_version":20210101,"functions":[{"name":"{{not a placeholder"}],"name":"eggcorn"}`), data)
+	})
+
+	t.Run("should fail when a placeholder has no value", func(t *testing.T) {
+		_, err := substitutePlaceholders(appData, map[string]string{"cluster_name": "Cluster0"})
+		assert.Equal(t, "missing value for placeholder: {{app_id}}", err.Error())
+	})
+}
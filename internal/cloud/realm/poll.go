@@ -0,0 +1,52 @@
+package realm
+
+import (
+	"context"
+	"time"
+)
+
+// set of polling defaults
+const (
+	defaultPollInterval = time.Second
+	defaultPollJitter   = 0.2
+)
+
+// pollOptions are options to configure polling for the completion of an async operation
+type pollOptions struct {
+	// interval is the delay between two polls
+	interval time.Duration
+
+	// jitter is the fraction (between 0 and 1) by which each interval is randomly
+	// increased or decreased to avoid polling in lockstep with other clients
+	jitter float64
+
+	// timeout is the maximum duration to poll for, polling never times out if unset
+	timeout time.Duration
+}
+
+// pollUntil calls the function at each interval until it reports the operation is done
+// or fails, polling fails with ErrWaitTimeout once the timeout is exceeded
+func pollUntil(opts pollOptions, done func() (bool, error)) error {
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	delay := RetryOptions{BaseDelay: opts.interval, MaxDelay: opts.interval, Jitter: opts.jitter}.withDefaults()
+
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		if err := sleepContext(ctx, delay.delay(1, retryJitter())); err != nil {
+			return ErrWaitTimeout{opts.timeout}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package realm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestPollUntil(t *testing.T) {
+	t.Run("should poll until the operation is done", func(t *testing.T) {
+		var calls int
+		err := pollUntil(pollOptions{interval: time.Millisecond}, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("should stop with the error of the poll", func(t *testing.T) {
+		var calls int
+		err := pollUntil(pollOptions{interval: time.Millisecond}, func() (bool, error) {
+			calls++
+			return false, errors.New("something bad happened")
+		})
+		assert.Equal(t, errors.New("something bad happened"), err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should fail with a timeout error once the timeout is exceeded", func(t *testing.T) {
+		err := pollUntil(pollOptions{interval: time.Millisecond, jitter: defaultPollJitter, timeout: 10 * time.Millisecond}, func() (bool, error) {
+			return false, nil
+		})
+		assert.Equal(t, ErrWaitTimeout{10 * time.Millisecond}, err)
+	})
+}
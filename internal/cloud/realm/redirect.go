@@ -0,0 +1,44 @@
+package realm
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+// maxRedirects is the maximum number of redirects followed for a request,
+// which matches the Go standard library's default
+const maxRedirects = 10
+
+// newRedirectPolicy creates the policy deciding which redirects are followed, redirects are
+// only followed to the base url's host or one of the trusted hosts, and the Authorization header
+// dropped by the Go standard library on cross-host redirects is re-attached for them;
+// redirects to any other host fail with ErrUntrustedRedirect so the credentials never leak
+func newRedirectPolicy(baseURL string, trustedHosts []string) func(req *http.Request, via []*http.Request) error {
+	trusted := make(map[string]struct{}, len(trustedHosts)+1)
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		trusted[u.Host] = struct{}{}
+	}
+	for _, host := range trustedHosts {
+		trusted[host] = struct{}{}
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		_, hostTrusted := trusted[req.URL.Host]
+		_, hostnameTrusted := trusted[req.URL.Hostname()]
+		if !hostTrusted && !hostnameTrusted {
+			return ErrUntrustedRedirect{req.URL.Host}
+		}
+
+		if auth := via[0].Header.Get(api.HeaderAuthorization); auth != "" {
+			req.Header.Set(api.HeaderAuthorization, auth)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package realm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientRedirects(t *testing.T) {
+	for _, tc := range []struct {
+		description  string
+		location     string
+		trustedHosts []string
+		expectedErr  error
+		expectedAuth string
+	}{
+		{
+			description:  "should follow a redirect to the same host",
+			location:     "https://realm.mongodb.com/api/admin/v3.0/moved",
+			expectedAuth: "Bearer access-token",
+		},
+		{
+			description:  "should follow a redirect to a trusted host and re-attach the authorization header",
+			location:     "https://us-east-1.realm.mongodb.com/api/admin/v3.0/moved",
+			trustedHosts: []string{"us-east-1.realm.mongodb.com"},
+			expectedAuth: "Bearer access-token",
+		},
+		{
+			description:  "should follow a redirect to a trusted host and port",
+			location:     "https://us-east-1.realm.mongodb.com:8443/api/admin/v3.0/moved",
+			trustedHosts: []string{"us-east-1.realm.mongodb.com:8443"},
+			expectedAuth: "Bearer access-token",
+		},
+		{
+			description: "should refuse to follow a redirect to an untrusted host",
+			location:    "https://eggcorn.com/api/admin/v3.0/moved",
+			expectedErr: ErrUntrustedRedirect{"eggcorn.com"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			var redirected *http.Request
+
+			c := newTestClientWithOptions("https://realm.mongodb.com", ClientOptions{
+				TrustedRedirectHosts: tc.trustedHosts,
+			}, func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/api/admin/v3.0/path" {
+					res := testResponse(req, http.StatusFound, "")
+					res.Header.Set("Location", tc.location)
+					return res, nil
+				}
+				redirected = req
+				return testResponse(req, http.StatusOK, ""), nil
+			})
+
+			res, err := c.do(http.MethodGet, adminAPI+"/path", api.RequestOptions{})
+			if tc.expectedErr != nil {
+				var redirectErr ErrUntrustedRedirect
+				assert.True(t, errors.As(err, &redirectErr), "expected an untrusted redirect error but got: %v", err)
+				assert.Equal(t, tc.expectedErr, redirectErr)
+				assert.Nil(t, redirected)
+				return
+			}
+
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, tc.location, redirected.URL.String())
+			assert.Equal(t, tc.expectedAuth, redirected.Header.Get(api.HeaderAuthorization))
+		})
+	}
+
+	t.Run("should stop after too many redirects", func(t *testing.T) {
+		policy := newRedirectPolicy("https://realm.mongodb.com", nil)
+
+		req, err := http.NewRequest(http.MethodGet, "https://realm.mongodb.com/path", nil)
+		assert.Nil(t, err)
+
+		err = policy(req, make([]*http.Request, maxRedirects))
+		assert.Equal(t, errors.New("stopped after 10 redirects"), err)
+	})
+}
@@ -0,0 +1,61 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	resourcePathPattern = appPathPattern + "/%s"
+)
+
+func (c *client) FetchResource(groupID, appID, resourcePath string) (json.RawMessage, error) {
+	resourcePath, err := validateResourcePath(resourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(resourcePathPattern, groupID, appID, resourcePath),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get resource", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("failed to decode resource '%s': response is not valid json", resourcePath)
+	}
+	return json.RawMessage(data), nil
+}
+
+// validateResourcePath checks that the resource path stays within the app's resources,
+// so it must be made of named segments only, without any query, fragment or escapes
+// the server could resolve outside of them; a leading slash is allowed and trimmed
+func validateResourcePath(resourcePath string) (string, error) {
+	trimmed := strings.TrimPrefix(resourcePath, "/")
+	if trimmed == "" || strings.ContainsAny(trimmed, `?#%\`) {
+		return "", ErrInvalidResourcePath{resourcePath}
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		switch segment {
+		case "", ".", "..":
+			return "", ErrInvalidResourcePath{resourcePath}
+		}
+	}
+	return trimmed, nil
+}
@@ -0,0 +1,59 @@
+package realm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestFetchResource(t *testing.T) {
+	newResourceClient := func(payload string, sent *string) Client {
+		return newTestClient(func(req *http.Request) (*http.Response, error) {
+			*sent = req.URL.Path
+			return testResponse(req, http.StatusOK, payload), nil
+		})
+	}
+
+	t.Run("should get the raw json of the resource under the app", func(t *testing.T) {
+		var sent string
+		client := newResourceClient(`{"_id":"ruleID","collection":"users"}`, &sent)
+
+		data, err := client.FetchResource("groupID", "appID", "/services/serviceID/rules/ruleID")
+		assert.Nil(t, err)
+		assert.Equal(t, json.RawMessage(`{"_id":"ruleID","collection":"users"}`), data)
+		assert.Equal(t, "/api/admin/v3.0/groups/groupID/apps/appID/services/serviceID/rules/ruleID", sent)
+	})
+
+	t.Run("should fail when the response is not json", func(t *testing.T) {
+		var sent string
+		client := newResourceClient("not json", &sent)
+
+		_, err := client.FetchResource("groupID", "appID", "functions")
+		assert.Equal(t, errors.New("failed to decode resource 'functions': response is not valid json"), err)
+	})
+
+	for _, resourcePath := range []string{
+		"",
+		"/",
+		"../../apps/otherAppID",
+		"services/../../otherAppID",
+		"services//rules",
+		"./functions",
+		"functions?product=atlas",
+		"functions#fragment",
+		"%2e%2e/otherAppID",
+		`services\rules`,
+	} {
+		t.Run("should fail with a resource path outside of the app: "+resourcePath, func(t *testing.T) {
+			var sent string
+			client := newResourceClient("{}", &sent)
+
+			_, err := client.FetchResource("groupID", "appID", resourcePath)
+			assert.Equal(t, ErrInvalidResourcePath{resourcePath}, err)
+			assert.Equal(t, "", sent)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package realm
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// set of retry defaults
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+)
+
+// defaultRetryableStatuses are the status codes of transient server failures
+// which may succeed when retried
+var defaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryOptions are options to configure how requests failing with
+// a transient server error are retried, each field left unset uses its default
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts made for a request,
+	// including the first one, defaults to 3
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, which doubles with each
+	// subsequent retry, defaults to 500ms
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, defaults to 10s
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (between 0 and 1) by which each delay is randomly
+	// increased or decreased to avoid retrying in lockstep with other clients,
+	// defaults to no jitter
+	Jitter float64
+
+	// RetryableStatuses are the status codes considered transient,
+	// defaults to 429, 502, 503 and 504; credential failures such as
+	// 401 and 403 are never retried, even when listed here
+	RetryableStatuses []int
+
+	// ShouldRetry decides whether an attempt should be retried given its response,
+	// or the error of an attempt failing without one, in place of RetryableStatuses;
+	// the response body can be inspected and is readable again afterwards
+	ShouldRetry func(res *http.Response, err error) bool
+}
+
+// withDefaults returns the retry options with the defaults set for each unset field
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = defaultRetryBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = defaultRetryMaxDelay
+	}
+	if o.Jitter < 0 {
+		o.Jitter = 0
+	} else if o.Jitter > 1 {
+		o.Jitter = 1
+	}
+	if len(o.RetryableStatuses) == 0 {
+		o.RetryableStatuses = defaultRetryableStatuses
+	}
+	return o
+}
+
+// isRetryableStatus reports whether the status code indicates a transient
+// server failure which may succeed when retried
+func (o RetryOptions) isRetryableStatus(statusCode int) bool {
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return false
+	}
+	for _, status := range o.RetryableStatuses {
+		if status == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry reports whether the attempt with the provided outcome should be retried,
+// the response body is buffered beforehand when a ShouldRetry predicate inspects it
+func (o RetryOptions) shouldRetry(res *http.Response, err error) (bool, error) {
+	if o.ShouldRetry == nil {
+		return err == nil && o.isRetryableStatus(res.StatusCode), nil
+	}
+	if res == nil {
+		return o.ShouldRetry(nil, err), nil
+	}
+
+	body, readErr := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if readErr != nil {
+		return false, readErr
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	ok := o.ShouldRetry(res, err)
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return ok, nil
+}
+
+// delay returns how long to wait after the provided failed attempt,
+// where r is a random value in [0, 1) used to apply the jitter
+func (o RetryOptions) delay(attempt int, r float64) time.Duration {
+	d := o.BaseDelay
+	for i := 1; i < attempt && d < o.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > o.MaxDelay {
+		d = o.MaxDelay
+	}
+	if o.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + o.Jitter*(2*r-1)))
+	}
+	return d
+}
+
+// sleepContext waits for the provided duration, returning early with
+// the context's error if it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryJitter returns the random value used to apply the retry jitter
+func retryJitter() float64 {
+	return rand.Float64() //nolint: gosec
+}
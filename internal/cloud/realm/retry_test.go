@@ -0,0 +1,198 @@
+package realm
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRetryOptionsWithDefaults(t *testing.T) {
+	t.Run("should set the defaults for unset fields", func(t *testing.T) {
+		assert.Equal(t, RetryOptions{
+			MaxAttempts:       3,
+			BaseDelay:         500 * time.Millisecond,
+			MaxDelay:          10 * time.Second,
+			RetryableStatuses: defaultRetryableStatuses,
+		}, RetryOptions{}.withDefaults())
+	})
+
+	t.Run("should keep the fields that are set", func(t *testing.T) {
+		opts := RetryOptions{
+			MaxAttempts:       5,
+			BaseDelay:         time.Second,
+			MaxDelay:          time.Minute,
+			Jitter:            0.5,
+			RetryableStatuses: []int{http.StatusInternalServerError},
+		}
+		assert.Equal(t, opts, opts.withDefaults())
+	})
+
+	t.Run("should clamp the jitter", func(t *testing.T) {
+		assert.Equal(t, 0.0, RetryOptions{Jitter: -1}.withDefaults().Jitter)
+		assert.Equal(t, 1.0, RetryOptions{Jitter: 2}.withDefaults().Jitter)
+	})
+}
+
+func TestRetryOptionsIsRetryableStatus(t *testing.T) {
+	opts := RetryOptions{}.withDefaults()
+
+	for _, tc := range []struct {
+		statusCode int
+		retryable  bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusForbidden, false},
+		{http.StatusInternalServerError, false},
+	} {
+		t.Run("should report whether status "+http.StatusText(tc.statusCode)+" is retryable", func(t *testing.T) {
+			assert.Equal(t, tc.retryable, opts.isRetryableStatus(tc.statusCode))
+		})
+	}
+
+	t.Run("should never retry credential failures", func(t *testing.T) {
+		opts := RetryOptions{RetryableStatuses: []int{http.StatusUnauthorized, http.StatusForbidden}}
+		assert.False(t, opts.isRetryableStatus(http.StatusUnauthorized), "expected 401 to not be retryable")
+		assert.False(t, opts.isRetryableStatus(http.StatusForbidden), "expected 403 to not be retryable")
+	})
+}
+
+func TestRetryOptionsShouldRetry(t *testing.T) {
+	t.Run("should retry the retryable statuses without a predicate", func(t *testing.T) {
+		opts := RetryOptions{}.withDefaults()
+
+		ok, err := opts.shouldRetry(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected 503 to be retried")
+
+		ok, err = opts.shouldRetry(nil, errors.New("connection reset"))
+		assert.Nil(t, err)
+		assert.False(t, ok, "expected a failed request to not be retried")
+	})
+
+	t.Run("should decide with the predicate and keep the response body readable", func(t *testing.T) {
+		opts := RetryOptions{ShouldRetry: func(res *http.Response, err error) bool {
+			if err != nil {
+				return true
+			}
+			body, readErr := ioutil.ReadAll(res.Body)
+			assert.Nil(t, readErr)
+			return strings.Contains(string(body), `"error_code":"Transient"`)
+		}}.withDefaults()
+
+		res := &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"error":"try again","error_code":"Transient"}`)),
+		}
+
+		ok, err := opts.shouldRetry(res, nil)
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected the transient error to be retried")
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"error":"try again","error_code":"Transient"}`, string(body))
+
+		ok, err = opts.shouldRetry(nil, errors.New("connection reset"))
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected the failed request to be retried")
+	})
+}
+
+func TestClientRetriesExhausted(t *testing.T) {
+	newRetryClient := func(statuses []int, attempts *int) Client {
+		return newTestClientWithOptions("http://localhost:8081", ClientOptions{
+			Retry: RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		}, func(req *http.Request) (*http.Response, error) {
+			status := statuses[*attempts]
+			*attempts++
+			if status == 0 {
+				return nil, errors.New("connection reset")
+			}
+			return testResponse(req, status, `{"error":"try again later"}`), nil
+		})
+	}
+
+	t.Run("should report the status of the final attempt", func(t *testing.T) {
+		var attempts int
+		client := newRetryClient([]int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusServiceUnavailable}, &attempts)
+
+		_, err := client.DoRequest(http.MethodGet, "/path", api.RequestOptions{NoAuth: true, Retry: true})
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, ErrRetriesExhausted{
+			Attempts:   3,
+			StatusCode: http.StatusServiceUnavailable,
+			Err:        ServerError{Message: "try again later"},
+		}, err)
+		assert.Equal(t, "request failed after 3 attempts with status 503: try again later", err.Error())
+
+		var serverErr ServerError
+		assert.True(t, errors.As(err, &serverErr), "expected the final attempt's server error to be wrapped")
+	})
+
+	t.Run("should not report a single failure as exhausted", func(t *testing.T) {
+		var attempts int
+		client := newRetryClient([]int{http.StatusBadRequest}, &attempts)
+
+		_, err := client.DoRequest(http.MethodGet, "/path", api.RequestOptions{NoAuth: true, Retry: true})
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, ServerError{Message: "try again later"}, err)
+	})
+
+	t.Run("should return the response of a final attempt that succeeded", func(t *testing.T) {
+		var attempts int
+		client := newRetryClient([]int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}, &attempts)
+
+		res, err := client.DoRequest(http.MethodGet, "/path", api.RequestOptions{NoAuth: true, Retry: true})
+		assert.Nil(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
+
+func TestRetryOptionsDelay(t *testing.T) {
+	t.Run("should double the delay with each attempt", func(t *testing.T) {
+		opts := RetryOptions{}.withDefaults()
+		assert.Equal(t, 500*time.Millisecond, opts.delay(1, 0))
+		assert.Equal(t, time.Second, opts.delay(2, 0))
+		assert.Equal(t, 2*time.Second, opts.delay(3, 0))
+	})
+
+	t.Run("should cap the delay", func(t *testing.T) {
+		opts := RetryOptions{MaxDelay: 3 * time.Second}.withDefaults()
+		assert.Equal(t, 3*time.Second, opts.delay(4, 0))
+		assert.Equal(t, 3*time.Second, opts.delay(100, 0))
+	})
+
+	t.Run("should apply the jitter", func(t *testing.T) {
+		opts := RetryOptions{Jitter: 0.5}.withDefaults()
+		assert.Equal(t, time.Second/4, opts.delay(1, 0))
+		assert.Equal(t, time.Second/2, opts.delay(1, 0.5))
+		assert.Equal(t, 750*time.Millisecond, opts.delay(1, 1))
+	})
+}
+
+func TestSleepContext(t *testing.T) {
+	t.Run("should return early once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Equal(t, context.Canceled, sleepContext(ctx, time.Hour))
+	})
+
+	t.Run("should wait for the duration without a context", func(t *testing.T) {
+		assert.Nil(t, sleepContext(nil, time.Millisecond))
+	})
+}
@@ -9,6 +9,8 @@ import (
 )
 
 const (
+	schemasPathPattern           = appPathPattern + "/schemas"
+	schemaPathPattern            = schemasPathPattern + "/%s"
 	syncClientSchemasPathPattern = appPathPattern + "/sync/client_schemas/%s"
 )
 
@@ -41,6 +43,131 @@ type SchemaModelAlert struct {
 	Code    string `json:"error_code"`
 }
 
+// Schema is a Realm app collection schema
+type Schema struct {
+	ID       string          `json:"_id"`
+	Metadata SchemaMetadata  `json:"metadata"`
+	Schema   json.RawMessage `json:"schema"`
+}
+
+// SchemaMetadata is the collection a Realm app schema is defined for
+type SchemaMetadata struct {
+	ServiceID  string `json:"service_id,omitempty"`
+	DataSource string `json:"data_source,omitempty"`
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+}
+
+func (c *client) Schemas(groupID, appID string) ([]Schema, error) {
+	res, err := c.do(
+		http.MethodGet,
+		fmt.Sprintf(schemasPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get schemas", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var schemas []Schema
+	if err := json.NewDecoder(res.Body).Decode(&schemas); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+func (c *client) FindSchema(groupID, appID, serviceID, database, collection string) (Schema, error) {
+	schemas, err := c.Schemas(groupID, appID)
+	if err != nil {
+		return Schema{}, err
+	}
+	return findSchema(schemas, serviceID, database, collection)
+}
+
+// findSchema finds the schema defined for the collection of the data source,
+// as the same namespace may have a schema in each of the app's data sources
+func findSchema(schemas []Schema, serviceID, database, collection string) (Schema, error) {
+	for _, schema := range schemas {
+		if schema.Metadata.ServiceID == serviceID &&
+			schema.Metadata.Database == database &&
+			schema.Metadata.Collection == collection {
+			return schema, nil
+		}
+	}
+	return Schema{}, ErrSchemaNotFound{serviceID, database, collection}
+}
+
+func (c *client) UpsertSchema(groupID, appID, serviceID, database, collection string, schema json.RawMessage) error {
+	if err := validateSchema(schema); err != nil {
+		return err
+	}
+
+	schemas, err := c.Schemas(groupID, appID)
+	if err != nil {
+		return err
+	}
+
+	payload := Schema{
+		Metadata: SchemaMetadata{ServiceID: serviceID, Database: database, Collection: collection},
+		Schema:   schema,
+	}
+
+	existing, err := findSchema(schemas, serviceID, database, collection)
+	if err != nil {
+		if _, ok := err.(ErrSchemaNotFound); !ok {
+			return err
+		}
+		return c.createSchema(groupID, appID, payload)
+	}
+
+	payload.ID = existing.ID
+	return c.updateSchema(groupID, appID, payload)
+}
+
+func (c *client) createSchema(groupID, appID string, schema Schema) error {
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(schemasPathPattern, groupID, appID),
+		schema,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusCreated {
+		return api.ErrUnexpectedStatusCode{"create schema", res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) updateSchema(groupID, appID string, schema Schema) error {
+	res, resErr := c.doJSON(
+		http.MethodPut,
+		fmt.Sprintf(schemaPathPattern, groupID, appID, schema.ID),
+		schema,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"update schema", res.StatusCode}
+	}
+	return nil
+}
+
+// validateSchema ensures the schema is a well-formed json document
+func validateSchema(schema json.RawMessage) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return fmt.Errorf("invalid schema: %s", err)
+	}
+	return nil
+}
+
 func (c *client) SchemaModels(groupID, appID, language string) ([]SchemaModel, error) {
 	res, err := c.do(
 		http.MethodGet,
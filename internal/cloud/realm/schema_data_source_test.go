@@ -0,0 +1,60 @@
+package realm
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientSchemaDataSources(t *testing.T) {
+	const schemasPath = "/api/admin/v3.0/groups/groupID/apps/appID/schemas"
+
+	newSchemaClient := func(requests *[]string) Client {
+		return newTestClient(func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+
+			switch {
+			case req.URL.Path == schemasPath && req.Method == http.MethodGet:
+				return testResponse(req, http.StatusOK, `[
+					{"_id":"schema1","metadata":{"service_id":"service1","database":"db","collection":"coll"},"schema":{"title":"one"}},
+					{"_id":"schema2","metadata":{"service_id":"service2","database":"db","collection":"coll"},"schema":{"title":"two"}}
+				]`), nil
+			case strings.HasPrefix(req.URL.Path, schemasPath+"/") && req.Method == http.MethodPut:
+				return testResponse(req, http.StatusNoContent, ""), nil
+			}
+			return testResponse(req, http.StatusNotFound, ""), nil
+		})
+	}
+
+	t.Run("should find the schema of the data source", func(t *testing.T) {
+		var requests []string
+		client := newSchemaClient(&requests)
+
+		schema, err := client.FindSchema("groupID", "appID", "service2", "db", "coll")
+		assert.Nil(t, err)
+		assert.Equal(t, "schema2", schema.ID)
+		assert.Equal(t, json.RawMessage(`{"title":"two"}`), schema.Schema)
+	})
+
+	t.Run("should fail to find a schema for another data source", func(t *testing.T) {
+		var requests []string
+		client := newSchemaClient(&requests)
+
+		_, err := client.FindSchema("groupID", "appID", "service3", "db", "coll")
+		assert.Equal(t, ErrSchemaNotFound{"service3", "db", "coll"}, err)
+	})
+
+	t.Run("should update the schema of the data source", func(t *testing.T) {
+		var requests []string
+		client := newSchemaClient(&requests)
+
+		assert.Nil(t, client.UpsertSchema("groupID", "appID", "service2", "db", "coll", json.RawMessage(`{"title":"updated"}`)))
+		assert.Equal(t, []string{
+			"GET " + schemasPath,
+			"PUT " + schemasPath + "/schema2",
+		}, requests)
+	})
+}
@@ -1,6 +1,7 @@
 package realm_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
@@ -25,6 +26,27 @@ func TestRealmSchemaModels(t *testing.T) {
 		app, teardown := setupTestApp(t, client, groupID, "schema-test")
 		defer teardown()
 
+		t.Run("should respond with an empty list of schemas with no app schema", func(t *testing.T) {
+			schemas, err := client.Schemas(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(schemas))
+		})
+
+		t.Run("should fail to find a schema with no app schema", func(t *testing.T) {
+			_, err := client.FindSchema(groupID, app.ID, "serviceID", "db", "coll")
+			assert.Equal(t, realm.ErrSchemaNotFound{"serviceID", "db", "coll"}, err)
+		})
+
+		t.Run("should fail to upsert a schema that is not valid json", func(t *testing.T) {
+			err := client.UpsertSchema(groupID, app.ID, "serviceID", "db", "coll", json.RawMessage(`{"title":`))
+			assert.Equal(t, "invalid schema: unexpected end of JSON input", err.Error())
+		})
+
+		t.Run("should fail to upsert a schema for a data source that does not exist", func(t *testing.T) {
+			err := client.UpsertSchema(groupID, app.ID, "serviceID", "db", "coll", json.RawMessage(`{"title":"coll"}`))
+			assert.NotNil(t, err)
+		})
+
 		t.Run("should respond with an empty list of data models with no app schema", func(t *testing.T) {
 			models, err := client.SchemaModels(groupID, app.ID, realm.DataModelLanguageTypescript)
 			assert.Nil(t, err)
@@ -1,5 +1,18 @@
 package realm
 
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	servicesPathPattern    = appPathPattern + "/services"
+	servicePathPattern     = servicesPathPattern + "/%s"
+	serviceTestPathPattern = servicePathPattern + "/commands/test"
+)
+
 // set of supported data source types
 const (
 	ServiceTypeCluster  = "mongodb-atlas"
@@ -10,3 +23,20 @@ const (
 const (
 	DefaultServiceNameCluster = "mongodb-atlas"
 )
+
+func (c *client) TestDataSource(groupID, appID, serviceID string) error {
+	res, resErr := c.do(
+		http.MethodPost,
+		fmt.Sprintf(serviceTestPathPattern, groupID, appID, serviceID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"test data source", res.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRealmTestDataSource(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		err := client.TestDataSource(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+		groupID := u.CloudGroupID()
+
+		testApp, teardown := setupTestApp(t, client, groupID, "services-test")
+		defer teardown()
+
+		t.Run("should fail to test a data source that does not exist", func(t *testing.T) {
+			err := client.TestDataSource(groupID, testApp.ID, primitive.NewObjectID().Hex())
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should fail to get the default rule of a service that does not exist", func(t *testing.T) {
+			_, err := client.DefaultRule(groupID, testApp.ID, primitive.NewObjectID().Hex())
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should fail to set the default rule of a service that does not exist", func(t *testing.T) {
+			_, err := client.SetDefaultRule(groupID, testApp.ID, primitive.NewObjectID().Hex(), realm.DefaultRule{
+				Roles: []realm.RuleRole{{Name: "readAll", Read: []byte("true")}},
+			})
+			assert.NotNil(t, err)
+		})
+
+		t.Run("should fail to list the webhooks of a service that does not exist", func(t *testing.T) {
+			_, err := client.Webhooks(groupID, testApp.ID, primitive.NewObjectID().Hex())
+			assert.NotNil(t, err)
+		})
+	})
+}
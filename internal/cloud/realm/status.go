@@ -1,6 +1,7 @@
 package realm
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 
@@ -9,11 +10,15 @@ import (
 
 const (
 	statusPath = privateAPI + "/version"
+
+	serverVersionHeader = "X-BAAS-Version"
 )
 
 // set of supported status errors
 var (
 	ErrServerUnavailable = errors.New("Realm server is not available")
+
+	errServerVersionUnknown = errors.New("failed to determine the Realm server version")
 )
 
 func (c *client) Status() error {
@@ -26,3 +31,37 @@ func (c *client) Status() error {
 	}
 	return nil
 }
+
+type serverVersionResponse struct {
+	Version string `json:"version"`
+}
+
+func (c *client) ServerVersion() (string, error) {
+	c.serverVersionMu.Lock()
+	defer c.serverVersionMu.Unlock()
+
+	if c.serverVersion != "" {
+		return c.serverVersion, nil
+	}
+
+	res, resErr := c.do(http.MethodGet, statusPath, api.RequestOptions{NoAuth: true})
+	if resErr != nil {
+		return "", resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", api.ErrUnexpectedStatusCode{"get server version", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var payload serverVersionResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil || payload.Version == "" {
+		// fallback to the version the server reports with each of its responses
+		payload.Version = res.Header.Get(serverVersionHeader)
+	}
+	if payload.Version == "" {
+		return "", errServerVersionUnknown
+	}
+
+	c.serverVersion = payload.Version
+	return c.serverVersion, nil
+}
@@ -18,6 +18,23 @@ func TestRealmStatus(t *testing.T) {
 	})
 }
 
+func TestRealmServerVersion(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+	client := realm.NewClient(u.RealmServerURL())
+
+	t.Run("Should return the server version", func(t *testing.T) {
+		version, err := client.ServerVersion()
+		assert.Nil(t, err)
+		assert.NotEqualf(t, "", version, "expected server version to be set")
+
+		t.Run("And return the same version once cached", func(t *testing.T) {
+			cached, err := client.ServerVersion()
+			assert.Nil(t, err)
+			assert.Equal(t, version, cached)
+		})
+	})
+}
+
 func TestRealmStatusFailure(t *testing.T) {
 	baseURL := "http://localhost:8081"
 	client := realm.NewClient(baseURL)
@@ -0,0 +1,172 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	serviceConfigPathPattern = servicePathPattern + "/config"
+)
+
+// set of sync modes
+const (
+	SyncModePartition SyncMode = "partition"
+	SyncModeFlexible  SyncMode = "flexible"
+)
+
+// SyncMode is the mode of a Realm app's sync
+type SyncMode string
+
+// set of sync states
+const (
+	SyncStateEnabled  = "enabled"
+	SyncStateDisabled = "disabled"
+)
+
+// SyncConfig is the configuration and state of a Realm app's sync,
+// which is set up on the data source linked to it
+type SyncConfig struct {
+	ServiceID    string
+	DataSource   string
+	Mode         SyncMode
+	State        string
+	DatabaseName string
+}
+
+type syncService struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type syncServiceConfig struct {
+	Sync         map[string]interface{} `json:"sync,omitempty"`
+	FlexibleSync map[string]interface{} `json:"flexible_sync,omitempty"`
+}
+
+func (c *client) SyncConfig(groupID, appID string) (SyncConfig, error) {
+	config, _, err := c.findSyncConfig(groupID, appID)
+	return config, err
+}
+
+func (c *client) PauseSync(groupID, appID string) error {
+	return c.setSyncState(groupID, appID, SyncStateDisabled)
+}
+
+func (c *client) ResumeSync(groupID, appID string) error {
+	return c.setSyncState(groupID, appID, SyncStateEnabled)
+}
+
+func (c *client) setSyncState(groupID, appID, state string) error {
+	config, serviceConfig, err := c.findSyncConfig(groupID, appID)
+	if err != nil {
+		return err
+	}
+
+	var patch syncServiceConfig
+	switch config.Mode {
+	case SyncModeFlexible:
+		serviceConfig.FlexibleSync["state"] = state
+		patch.FlexibleSync = serviceConfig.FlexibleSync
+	default:
+		serviceConfig.Sync["state"] = state
+		patch.Sync = serviceConfig.Sync
+	}
+
+	res, resErr := c.doJSON(
+		http.MethodPatch,
+		fmt.Sprintf(serviceConfigPathPattern, groupID, appID, config.ServiceID),
+		patch,
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"set sync state", res.StatusCode}
+	}
+	return nil
+}
+
+// findSyncConfig finds the sync config of the app's cluster data source set up for sync,
+// alongside the data source's sync section which can be patched to update it
+func (c *client) findSyncConfig(groupID, appID string) (SyncConfig, syncServiceConfig, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(servicesPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return SyncConfig{}, syncServiceConfig{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return SyncConfig{}, syncServiceConfig{}, api.ErrUnexpectedStatusCode{"get services", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var services []syncService
+	if err := json.NewDecoder(res.Body).Decode(&services); err != nil {
+		return SyncConfig{}, syncServiceConfig{}, err
+	}
+
+	for _, service := range services {
+		if service.Type != ServiceTypeCluster {
+			continue
+		}
+
+		serviceConfig, err := c.serviceSyncConfig(groupID, appID, service.ID)
+		if err != nil {
+			return SyncConfig{}, syncServiceConfig{}, err
+		}
+
+		config := SyncConfig{ServiceID: service.ID, DataSource: service.Name}
+
+		var section map[string]interface{}
+		switch {
+		case len(serviceConfig.FlexibleSync) > 0:
+			config.Mode = SyncModeFlexible
+			section = serviceConfig.FlexibleSync
+		case len(serviceConfig.Sync) > 0:
+			config.Mode = SyncModePartition
+			section = serviceConfig.Sync
+		default:
+			continue
+		}
+
+		config.State, _ = section["state"].(string)
+		if config.State == "" {
+			continue
+		}
+		config.DatabaseName, _ = section["database_name"].(string)
+
+		return config, serviceConfig, nil
+	}
+	return SyncConfig{}, syncServiceConfig{}, ErrSyncNotEnabled
+}
+
+func (c *client) serviceSyncConfig(groupID, appID, serviceID string) (syncServiceConfig, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(serviceConfigPathPattern, groupID, appID, serviceID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return syncServiceConfig{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return syncServiceConfig{}, api.ErrUnexpectedStatusCode{"get service config", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var config syncServiceConfig
+	if err := json.NewDecoder(res.Body).Decode(&config); err != nil {
+		return syncServiceConfig{}, err
+	}
+	return config, nil
+}
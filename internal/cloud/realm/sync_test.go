@@ -0,0 +1,89 @@
+package realm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestClientSync(t *testing.T) {
+	const (
+		servicesPath = "/api/admin/v3.0/groups/groupID/apps/appID/services"
+		configPath   = servicesPath + "/serviceID/config"
+	)
+
+	newSyncClient := func(serviceConfig string, patched *string) Client {
+		return newTestClient(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Path == servicesPath:
+				return testResponse(req, http.StatusOK, `[
+					{"_id":"httpID","name":"http","type":"http"},
+					{"_id":"serviceID","name":"mongodb-atlas","type":"mongodb-atlas"}
+				]`), nil
+			case req.URL.Path == configPath && req.Method == http.MethodGet:
+				return testResponse(req, http.StatusOK, serviceConfig), nil
+			case req.URL.Path == configPath && req.Method == http.MethodPatch:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				*patched = string(body)
+				return testResponse(req, http.StatusNoContent, ""), nil
+			}
+			return testResponse(req, http.StatusNotFound, ""), nil
+		})
+	}
+
+	t.Run("should get the partition based sync config", func(t *testing.T) {
+		var patched string
+		client := newSyncClient(`{"clusterName":"Cluster0","sync":{"state":"enabled","database_name":"todo","partition":{"key":"_pk"}}}`, &patched)
+
+		config, err := client.SyncConfig("groupID", "appID")
+		assert.Nil(t, err)
+		assert.Equal(t, SyncConfig{
+			ServiceID:    "serviceID",
+			DataSource:   "mongodb-atlas",
+			Mode:         SyncModePartition,
+			State:        SyncStateEnabled,
+			DatabaseName: "todo",
+		}, config)
+
+		t.Run("and pause sync keeping the rest of its config", func(t *testing.T) {
+			assert.Nil(t, client.PauseSync("groupID", "appID"))
+			assert.Equal(t, `{"sync":{"database_name":"todo","partition":{"key":"_pk"},"state":"disabled"}}`, patched)
+		})
+	})
+
+	t.Run("should get the flexible sync config", func(t *testing.T) {
+		var patched string
+		client := newSyncClient(`{"clusterName":"Cluster0","flexible_sync":{"state":"disabled","database_name":"todo"}}`, &patched)
+
+		config, err := client.SyncConfig("groupID", "appID")
+		assert.Nil(t, err)
+		assert.Equal(t, SyncConfig{
+			ServiceID:    "serviceID",
+			DataSource:   "mongodb-atlas",
+			Mode:         SyncModeFlexible,
+			State:        SyncStateDisabled,
+			DatabaseName: "todo",
+		}, config)
+
+		t.Run("and resume sync", func(t *testing.T) {
+			assert.Nil(t, client.ResumeSync("groupID", "appID"))
+			assert.Equal(t, `{"flexible_sync":{"database_name":"todo","state":"enabled"}}`, patched)
+		})
+	})
+
+	t.Run("should fail when sync is not enabled", func(t *testing.T) {
+		var patched string
+		client := newSyncClient(`{"clusterName":"Cluster0"}`, &patched)
+
+		_, err := client.SyncConfig("groupID", "appID")
+		assert.Equal(t, ErrSyncNotEnabled, err)
+
+		assert.Equal(t, ErrSyncNotEnabled, client.PauseSync("groupID", "appID"))
+		assert.Equal(t, "", patched)
+	})
+}
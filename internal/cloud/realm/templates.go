@@ -24,8 +24,9 @@ var AllowedTemplates = []string{
 
 // Template represents an available Realm app template
 type Template struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 // Templates is a slice of Template structs
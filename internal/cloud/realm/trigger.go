@@ -0,0 +1,68 @@
+package realm
+
+import (
+	"time"
+)
+
+// set of trigger execution statuses
+const (
+	TriggerExecutionStatusSuccess = "success"
+	TriggerExecutionStatusFailed  = "failed"
+)
+
+// triggerLogTypes are the log types of trigger executions
+var triggerLogTypes = []string{LogTypeAuthTrigger, LogTypeDBTrigger, LogTypeScheduledTrigger}
+
+// TriggerExecutionsOptions are options to query for a Realm app trigger's executions
+type TriggerExecutionsOptions struct {
+	ErrorsOnly bool
+	Start      time.Time
+	End        time.Time
+}
+
+// TriggerExecution is a single execution of a Realm app trigger
+type TriggerExecution struct {
+	RequestID string
+	Started   time.Time
+	Completed time.Time
+	Status    string
+	Error     string
+	ErrorCode string
+}
+
+func (c *client) TriggerExecutions(groupID, appID, triggerID string, opts TriggerExecutionsOptions) ([]TriggerExecution, error) {
+	logs, err := c.Logs(groupID, appID, LogsOptions{
+		ErrorsOnly: opts.ErrorsOnly,
+		Types:      triggerLogTypes,
+		Start:      opts.Start,
+		End:        opts.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return triggerExecutions(logs, triggerID), nil
+}
+
+func triggerExecutions(logs Logs, triggerID string) []TriggerExecution {
+	executions := []TriggerExecution{}
+	for _, log := range logs {
+		if log.EventSubscriptionID != triggerID {
+			continue
+		}
+
+		status := TriggerExecutionStatusSuccess
+		if log.Error != "" {
+			status = TriggerExecutionStatusFailed
+		}
+
+		executions = append(executions, TriggerExecution{
+			RequestID: log.RequestID,
+			Started:   log.Started,
+			Completed: log.Completed,
+			Status:    status,
+			Error:     log.Error,
+			ErrorCode: log.ErrorCode,
+		})
+	}
+	return executions
+}
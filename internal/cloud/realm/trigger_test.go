@@ -0,0 +1,60 @@
+package realm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestTriggerExecutions(t *testing.T) {
+	started := time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	logs := Logs{
+		{
+			RequestID:           "request1",
+			Type:                LogTypeDBTrigger,
+			Started:             started,
+			Completed:           started.Add(time.Second),
+			EventSubscriptionID: "triggerID",
+		},
+		{
+			RequestID:           "request2",
+			Type:                LogTypeDBTrigger,
+			Started:             started.Add(-time.Minute),
+			Completed:           started.Add(-time.Minute + time.Second),
+			Error:               "function not found",
+			ErrorCode:           "FunctionNotFound",
+			EventSubscriptionID: "triggerID",
+		},
+		{
+			RequestID:           "request3",
+			Type:                LogTypeScheduledTrigger,
+			Started:             started,
+			EventSubscriptionID: "otherTriggerID",
+		},
+	}
+
+	t.Run("should return the executions of the trigger", func(t *testing.T) {
+		assert.Equal(t, []TriggerExecution{
+			{
+				RequestID: "request1",
+				Started:   started,
+				Completed: started.Add(time.Second),
+				Status:    TriggerExecutionStatusSuccess,
+			},
+			{
+				RequestID: "request2",
+				Started:   started.Add(-time.Minute),
+				Completed: started.Add(-time.Minute + time.Second),
+				Status:    TriggerExecutionStatusFailed,
+				Error:     "function not found",
+				ErrorCode: "FunctionNotFound",
+			},
+		}, triggerExecutions(logs, "triggerID"))
+	})
+
+	t.Run("should return no executions for a trigger without logs", func(t *testing.T) {
+		assert.Equal(t, []TriggerExecution{}, triggerExecutions(logs, "missingTriggerID"))
+	})
+}
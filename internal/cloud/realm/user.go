@@ -2,8 +2,10 @@ package realm
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/10gen/realm-cli/internal/utils/api"
@@ -13,14 +15,18 @@ import (
 )
 
 const (
-	apiKeysPathPattern      = appPathPattern + "/api_keys"
-	pendingUsersPathPattern = appPathPattern + "/user_registrations/pending_users"
-	usersPathPattern        = appPathPattern + "/users"
-	userPathPattern         = usersPathPattern + "/%s"
-	userDisablePathPattern  = userPathPattern + "/disable"
-	userEnablePathPattern   = userPathPattern + "/enable"
-	userLogoutPathPattern   = userPathPattern + "/logout"
-
+	apiKeysPathPattern                       = appPathPattern + "/api_keys"
+	pendingUsersPathPattern                  = appPathPattern + "/user_registrations/pending_users"
+	userRegistrationPathPattern              = appPathPattern + "/user_registrations/by_email/%s"
+	userRegistrationConfirmPathPattern       = userRegistrationPathPattern + "/confirm"
+	userRegistrationResetPasswordPathPattern = userRegistrationPathPattern + "/reset_password"
+	usersPathPattern                         = appPathPattern + "/users"
+	userPathPattern                          = usersPathPattern + "/%s"
+	userDisablePathPattern                   = userPathPattern + "/disable"
+	userEnablePathPattern                    = userPathPattern + "/enable"
+	userLogoutPathPattern                    = userPathPattern + "/logout"
+
+	usersQueryAfter         = "after"
 	usersQueryStatus        = "status"
 	usersQueryProviderTypes = "provider_types"
 )
@@ -277,12 +283,67 @@ func (c *client) EnableUser(groupID, appID, userID string) error {
 	return nil
 }
 
+type resetUserPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+func (c *client) ConfirmUser(groupID, appID, email string) error {
+	res, resErr := c.do(
+		http.MethodPost,
+		fmt.Sprintf(userRegistrationConfirmPathPattern, groupID, appID, url.PathEscape(email)),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return userRegistrationError(resErr, email)
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"confirm user", res.StatusCode}
+	}
+	return nil
+}
+
+func (c *client) ResetUserPassword(groupID, appID, email, password string) error {
+	res, resErr := c.doJSON(
+		http.MethodPost,
+		fmt.Sprintf(userRegistrationResetPasswordPathPattern, groupID, appID, url.PathEscape(email)),
+		resetUserPasswordRequest{password},
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return userRegistrationError(resErr, email)
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{"reset user password", res.StatusCode}
+	}
+	return nil
+}
+
+// userRegistrationError maps the known server errors of user registrations to their typed errors
+func userRegistrationError(err error, email string) error {
+	var serverErr ServerError
+	if !errors.As(err, &serverErr) {
+		return err
+	}
+	switch serverErr.Code {
+	case errCodeUserAlreadyConfirmed:
+		return ErrUserAlreadyConfirmed{email}
+	case errCodeUserpassTokenInvalid:
+		return ErrUserTokenExpired{email}
+	}
+	return err
+}
+
 // UserFilter represents the optional filter parameters available for lists of users
 type UserFilter struct {
 	IDs       []string
 	Pending   bool
 	Providers []AuthProviderType
 	State     UserState
+
+	// After is the id of the last user of the previous page of users,
+	// which pages through the users of apps with too many users to list
+	// at once; it is ignored when filtering by ids or for pending users
+	After string
 }
 
 func (c *client) FindUsers(groupID, appID string, filter UserFilter) ([]User, error) {
@@ -290,7 +351,7 @@ func (c *client) FindUsers(groupID, appID string, filter UserFilter) ([]User, er
 		return c.getPendingUsers(groupID, appID, filter.IDs)
 	}
 	if len(filter.IDs) == 0 {
-		return c.getUsers(groupID, appID, filter.State, filter.Providers)
+		return c.getUsers(groupID, appID, filter.State, filter.Providers, filter.After)
 	}
 	return c.getUsersByIDs(groupID, appID, filter.IDs, filter.State, filter.Providers)
 }
@@ -369,8 +430,11 @@ func (c *client) getUser(groupID, appID, userID string) (User, error) {
 	return user, nil
 }
 
-func (c *client) getUsers(groupID, appID string, userState UserState, authProviderTypes AuthProviderTypes) ([]User, error) {
+func (c *client) getUsers(groupID, appID string, userState UserState, authProviderTypes AuthProviderTypes, after string) ([]User, error) {
 	options := api.RequestOptions{Query: make(map[string]string)}
+	if after != "" {
+		options.Query[usersQueryAfter] = after
+	}
 	if userState != UserStateNil {
 		options.Query[usersQueryStatus] = string(userState)
 	}
@@ -93,6 +93,16 @@ func TestRealmUsers(t *testing.T) {
 				}
 			})
 
+			t.Run("And page through users after a certain user", func(t *testing.T) {
+				users, err := client.FindUsers(groupID, app.ID, realm.UserFilter{})
+				assert.Nil(t, err)
+				assert.Equal(t, 5, len(users))
+
+				page, err := client.FindUsers(groupID, app.ID, realm.UserFilter{After: users[0].ID})
+				assert.Nil(t, err)
+				assert.Equal(t, users[1:], page)
+			})
+
 			t.Run("And find a certain type of user", func(t *testing.T) {
 				users, err := client.FindUsers(groupID, app.ID, realm.UserFilter{Providers: []realm.AuthProviderType{realm.AuthProviderTypeUserPassword}})
 				assert.Nil(t, err)
@@ -92,3 +92,44 @@ func TestAuthProviderTypesJoin(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRegistrationError(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		err         error
+		expectedErr error
+	}{
+		{
+			description: "should return an already confirmed error",
+			err:         ServerError{Code: errCodeUserAlreadyConfirmed, Message: "user already confirmed"},
+			expectedErr: ErrUserAlreadyConfirmed{"user@domain.com"},
+		},
+		{
+			description: "should return a token expired error",
+			err:         ServerError{Code: errCodeUserpassTokenInvalid, Message: "invalid token"},
+			expectedErr: ErrUserTokenExpired{"user@domain.com"},
+		},
+		{
+			description: "should return an already confirmed error for a permission denied error",
+			err: ErrPermissionDenied{
+				Resource:    "users",
+				ServerError: ServerError{Code: errCodeUserAlreadyConfirmed, Message: "user already confirmed"},
+			},
+			expectedErr: ErrUserAlreadyConfirmed{"user@domain.com"},
+		},
+		{
+			description: "should return any other server error as is",
+			err:         ServerError{Code: "SomethingBad", Message: "something bad happened"},
+			expectedErr: ServerError{Code: "SomethingBad", Message: "something bad happened"},
+		},
+		{
+			description: "should return any other error as is",
+			err:         ErrInvalidSession{},
+			expectedErr: ErrInvalidSession{},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, userRegistrationError(tc.err, "user@domain.com"))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	valuesPathPattern = appPathPattern + "/values"
+	valuePathPattern  = valuesPathPattern + "/%s"
+)
+
+// Value is a Realm app value
+type Value struct {
+	ID         string          `json:"_id"`
+	Name       string          `json:"name"`
+	Private    bool            `json:"private"`
+	FromSecret bool            `json:"from_secret"`
+	Value      json.RawMessage `json:"value,omitempty"`
+}
+
+func (c *client) Values(groupID, appID string) ([]Value, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(valuesPathPattern, groupID, appID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get values", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var values []Value
+	if err := json.NewDecoder(res.Body).Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (c *client) FindValue(groupID, appID, name string) (Value, error) {
+	values, err := c.Values(groupID, appID)
+	if err != nil {
+		return Value{}, err
+	}
+
+	for _, value := range values {
+		if value.Name == name {
+			return c.value(groupID, appID, value.ID)
+		}
+	}
+	return Value{}, ErrValueNotFound{name}
+}
+
+func (c *client) value(groupID, appID, valueID string) (Value, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(valuePathPattern, groupID, appID, valueID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return Value{}, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return Value{}, api.ErrUnexpectedStatusCode{"get value", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var value Value
+	if err := json.NewDecoder(res.Body).Decode(&value); err != nil {
+		return Value{}, err
+	}
+	return value, nil
+}
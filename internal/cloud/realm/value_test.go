@@ -0,0 +1,40 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmValues(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.Values(u.CloudGroupID(), "test-app-1234")
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+
+		groupID := u.CloudGroupID()
+
+		app, teardown := setupTestApp(t, client, groupID, "values-test")
+		defer teardown()
+
+		t.Run("should have no values upon app initialization", func(t *testing.T) {
+			values, err := client.Values(groupID, app.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(values))
+		})
+
+		t.Run("should fail to find a value that does not exist", func(t *testing.T) {
+			_, err := client.FindValue(groupID, app.ID, "missing")
+			assert.Equal(t, realm.ErrValueNotFound{"missing"}, err)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package realm
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+)
+
+// variablePattern matches the variables referenced in the app data (e.g. "${CLUSTER_NAME}")
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// variableSourceField is the field holding the javascript source of functions and webhooks,
+// which is left untouched as the variable syntax clashes with javascript template literals
+const variableSourceField = "source"
+
+// SubstituteVariables returns the json encoded app data with each of the variables
+// referenced in its string values (e.g. "${CLUSTER_NAME}") replaced by the provided
+// values, keyed by variable name; variables can be embedded within a longer string and
+// the substitution fails with ErrMissingVariables if any of them is left without a value.
+// Function and webhook sources are never substituted so they are deployed as written
+func SubstituteVariables(appData interface{}, variables map[string]string) (json.RawMessage, error) {
+	data, err := json.Marshal(appData)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	missing := map[string]struct{}{}
+	substituted := substituteJSONVariables(v, variables, missing)
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, ErrMissingVariables{names}
+	}
+	return json.Marshal(substituted)
+}
+
+func substituteJSONVariables(v interface{}, variables map[string]string, missing map[string]struct{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return variablePattern.ReplaceAllStringFunc(val, func(ref string) string {
+			name := variablePattern.FindStringSubmatch(ref)[1]
+			value, ok := variables[name]
+			if !ok {
+				missing[name] = struct{}{}
+				return ref
+			}
+			return value
+		})
+	case map[string]interface{}:
+		for key, elem := range val {
+			if _, ok := elem.(string); ok && key == variableSourceField {
+				continue
+			}
+			val[key] = substituteJSONVariables(elem, variables, missing)
+		}
+	case []interface{}:
+		for i, elem := range val {
+			val[i] = substituteJSONVariables(elem, variables, missing)
+		}
+	}
+	return v
+}
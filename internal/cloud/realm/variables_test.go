@@ -0,0 +1,57 @@
+package realm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	appData := map[string]interface{}{
+		"name": "eggcorn",
+		"data_sources": []interface{}{
+			map[string]interface{}{
+				"name":   "mongodb-atlas",
+				"config": map[string]interface{}{"clusterName": "${CLUSTER_NAME}"},
+			},
+		},
+		"values": map[string]interface{}{"url": "https://${HOST}:${PORT}/api"},
+		"count":  42,
+	}
+
+	t.Run("should substitute the variables", func(t *testing.T) {
+		data, err := SubstituteVariables(appData, map[string]string{
+			"CLUSTER_NAME": "Cluster0",
+			"HOST":         "eggcorn.com",
+			"PORT":         "8443",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"count":42,"data_sources":[{"config":{"clusterName":"Cluster0"},"name":"mongodb-atlas"}],"name":"eggcorn","values":{"url":"https://eggcorn.com:8443/api"}}`, string(data))
+	})
+
+	t.Run("should leave the function sources unchanged", func(t *testing.T) {
+		source := "exports = function(x){ return `hello ${x}`; };"
+
+		data, err := SubstituteVariables(map[string]interface{}{
+			"functions": []interface{}{
+				map[string]interface{}{"name": "greet", "source": source},
+			},
+		}, map[string]string{"x": "eggcorn"})
+		assert.Nil(t, err)
+
+		var substituted struct {
+			Functions []struct {
+				Source string `json:"source"`
+			} `json:"functions"`
+		}
+		assert.Nil(t, json.Unmarshal(data, &substituted))
+		assert.Equal(t, source, substituted.Functions[0].Source)
+	})
+
+	t.Run("should fail listing the variables without a value", func(t *testing.T) {
+		_, err := SubstituteVariables(appData, map[string]string{"HOST": "eggcorn.com"})
+		assert.Equal(t, ErrMissingVariables{[]string{"CLUSTER_NAME", "PORT"}}, err)
+		assert.Equal(t, "missing values for variables: CLUSTER_NAME, PORT", err.Error())
+	})
+}
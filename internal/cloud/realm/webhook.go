@@ -0,0 +1,69 @@
+package realm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/10gen/realm-cli/internal/utils/api"
+)
+
+const (
+	webhooksPathPattern       = servicePathPattern + "/incoming_webhooks"
+	webhookPathPattern        = webhooksPathPattern + "/%s"
+	webhookDisablePathPattern = webhookPathPattern + "/disable"
+	webhookEnablePathPattern  = webhookPathPattern + "/enable"
+)
+
+// Webhook is an incoming webhook of a Realm app service
+type Webhook struct {
+	ID         string `json:"_id"`
+	Name       string `json:"name"`
+	Route      string `json:"route,omitempty"`
+	HTTPMethod string `json:"http_method,omitempty"`
+	Disabled   bool   `json:"disabled"`
+}
+
+func (c *client) Webhooks(groupID, appID, serviceID string) ([]Webhook, error) {
+	res, resErr := c.do(
+		http.MethodGet,
+		fmt.Sprintf(webhooksPathPattern, groupID, appID, serviceID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return nil, resErr
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, api.ErrUnexpectedStatusCode{"get webhooks", res.StatusCode}
+	}
+	defer res.Body.Close()
+
+	var webhooks []Webhook
+	if err := json.NewDecoder(res.Body).Decode(&webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *client) DisableWebhook(groupID, appID, serviceID, webhookID string) error {
+	return c.toggleWebhook(webhookDisablePathPattern, "disable webhook", groupID, appID, serviceID, webhookID)
+}
+
+func (c *client) EnableWebhook(groupID, appID, serviceID, webhookID string) error {
+	return c.toggleWebhook(webhookEnablePathPattern, "enable webhook", groupID, appID, serviceID, webhookID)
+}
+
+func (c *client) toggleWebhook(pathPattern, action, groupID, appID, serviceID, webhookID string) error {
+	res, resErr := c.do(
+		http.MethodPut,
+		fmt.Sprintf(pathPattern, groupID, appID, serviceID, webhookID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{action, res.StatusCode}
+	}
+	return nil
+}
@@ -76,7 +76,9 @@ func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.C
 		return err
 	}
 
-	profile.SetSession(user.Session{session.AccessToken, session.RefreshToken})
+	if err := clients.Realm.SaveSession(session); err != nil {
+		return err
+	}
 	if err := profile.Save(); err != nil {
 		return err
 	}
@@ -31,7 +31,7 @@ func TestLoginHandler(t *testing.T) {
 		assert.NotNil(t, statErr)
 		assert.True(t, os.IsNotExist(statErr), "profile must not exist")
 
-		realmClient := mock.RealmClient{}
+		realmClient := mock.RealmClient{Client: realm.NewAuthClient(profile.RealmBaseURL(), profile)}
 		realmClient.AuthenticateFn = func(publicAPIKey, privateAPIKey string) (realm.Session, error) {
 			return realm.Session{
 				AccessToken:  "accessToken",
@@ -70,7 +70,7 @@ func TestLoginHandler(t *testing.T) {
 			profile.SetSession(user.Session{"existingAccessToken", "existingRefreshToken"})
 			assert.Nil(t, profile.Save())
 
-			realmClient := mock.RealmClient{}
+			realmClient := mock.RealmClient{Client: realm.NewAuthClient(profile.RealmBaseURL(), profile)}
 			realmClient.AuthenticateFn = func(publicAPIKey, privateAPIKey string) (realm.Session, error) {
 				return realm.Session{
 					AccessToken:  "newAccessToken",
@@ -163,6 +163,33 @@ func TestLoginHandler(t *testing.T) {
 	})
 }
 
+func TestLoginCredentialStore(t *testing.T) {
+	t.Run("should save the session through the realm client", func(t *testing.T) {
+		tc := setup(t)
+		defer tc.teardown()
+
+		var saved realm.Session
+
+		realmClient := tc.realmClient.(mock.RealmClient)
+		realmClient.SaveSessionFn = func(session realm.Session) error {
+			saved = session
+			return nil
+		}
+
+		cmd := &Command{inputs{
+			PublicAPIKey:  "existingUser",
+			PrivateAPIKey: "existing-password",
+		}}
+
+		_, ui := mock.NewUI()
+
+		assert.Nil(t, cmd.Handler(tc.profile, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, realm.Session{AccessToken: "newAccessToken", RefreshToken: "newRefreshToken"}, saved)
+		assert.Equal(t, user.Session{"existingAccessToken", "existingRefreshToken"}, tc.profile.Session())
+	})
+}
+
 func TestLoginFeedback(t *testing.T) {
 	t.Run("should print a message that login was successful", func(t *testing.T) {
 		tc := setup(t)
@@ -211,7 +238,7 @@ func setup(t *testing.T) testContext {
 	profile.SetSession(user.Session{"existingAccessToken", "existingRefreshToken"})
 	assert.Nil(t, profile.Save())
 
-	realmClient := mock.RealmClient{}
+	realmClient := mock.RealmClient{Client: realm.NewAuthClient(profile.RealmBaseURL(), profile)}
 	realmClient.AuthenticateFn = func(publicAPIKey, privateAPIKey string) (realm.Session, error) {
 		return realm.Session{
 			AccessToken:  "newAccessToken",
@@ -20,7 +20,9 @@ type Command struct{}
 // Handler is the command handler
 func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.Clients) error {
 	profile.ClearCredentials()
-	profile.ClearSession()
+	if err := clients.Realm.ClearSession(); err != nil {
+		return err
+	}
 
 	if err := profile.Save(); err != nil {
 		return err
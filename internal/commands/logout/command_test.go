@@ -8,6 +8,7 @@ import (
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
 	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
@@ -46,7 +47,7 @@ func TestLogoutHandler(t *testing.T) {
 
 		cmd := &Command{}
 
-		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realm.NewAuthClient(profile.RealmBaseURL(), profile)}))
 
 		assert.Equal(t, user.Credentials{}, profile.Credentials())
 		assert.Equal(t, user.Session{}, profile.Session())
@@ -62,6 +63,27 @@ func TestLogoutHandler(t *testing.T) {
 	})
 }
 
+func TestLogoutCredentialStore(t *testing.T) {
+	t.Run("should clear the session through the realm client", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		var cleared bool
+
+		realmClient := mock.RealmClient{}
+		realmClient.ClearSessionFn = func() error {
+			cleared = true
+			return nil
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &Command{}
+
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+		assert.True(t, cleared, "expected the session to be cleared")
+	})
+}
+
 func TestLogoutFeedback(t *testing.T) {
 	t.Run("should print a message that logout was successful", func(t *testing.T) {
 		profile := mock.NewProfile(t)
@@ -70,7 +92,7 @@ func TestLogoutFeedback(t *testing.T) {
 
 		cmd := &Command{}
 
-		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{}))
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realm.NewAuthClient(profile.RealmBaseURL(), profile)}))
 
 		assert.Equal(t, "Successfully logged out\n", out.String())
 	})
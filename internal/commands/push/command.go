@@ -1,9 +1,9 @@
 package push
 
 import (
+	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cli/user"
@@ -303,26 +303,11 @@ func (cmd *Command) Handler(profile *user.Profile, ui terminal.UI, clients cli.C
 			if err := clients.Realm.ImportDependencies(appRemote.GroupID, appRemote.AppID, uploadPathDependencies); err != nil {
 				return err
 			}
-
-			status := realm.DependenciesStatus{State: realm.DependenciesStateCreated}
-			for status.State == realm.DependenciesStateCreated {
-				var err error
-				status, err = clients.Realm.DependenciesStatus(appRemote.GroupID, appRemote.AppID)
-				if err != nil {
-					return err
-				}
-
-				if status.State == realm.DependenciesStateSuccessful || status.State == realm.DependenciesStateFailed {
-					break
+			return clients.Realm.WaitForDependencies(appRemote.GroupID, appRemote.AppID, 0, func(status realm.DependenciesStatus) {
+				if status.State == realm.DependenciesStateCreated {
+					s.SetMessage(fmt.Sprintf("Installing dependencies: %s...", status.Message))
 				}
-
-				s.SetMessage(fmt.Sprintf("Installing dependencies: %s...", status.Message))
-				time.Sleep(time.Second)
-			}
-			if status.State == realm.DependenciesStateFailed {
-				return fmt.Errorf("failed to install dependencies: %s", status.Message)
-			}
-			return nil
+			})
 		}
 
 		if err := installDependencies(); err != nil {
@@ -486,7 +471,8 @@ func createNewDraft(ui terminal.UI, realmClient realm.Client, remote appRemote)
 		return draft, true, nil
 	}
 
-	if err, ok := draftErr.(realm.ServerError); !ok || err.Code != realm.ErrCodeDraftAlreadyExists {
+	var serverErr realm.ServerError
+	if !errors.As(draftErr, &serverErr) || serverErr.Code != realm.ErrCodeDraftAlreadyExists {
 		return realm.AppDraft{}, false, draftErr
 	}
 
@@ -554,28 +540,26 @@ func deployDraftAndWait(ui terminal.UI, realmClient realm.Client, remote appRemo
 	s := ui.Spinner("Deploying app changes...", terminal.SpinnerOptions{})
 
 	waitForDeployment := func() error {
-		s.Start()
-		defer s.Stop()
-
-		for deployment.Status == realm.DeploymentStatusCreated || deployment.Status == realm.DeploymentStatusPending {
-			time.Sleep(time.Second)
-
-			deployment, err = realmClient.Deployment(remote.GroupID, remote.AppID, deployment.ID)
-			if err != nil {
-				return err
+		if deployment.Status != realm.DeploymentStatusCreated && deployment.Status != realm.DeploymentStatusPending {
+			if deployment.Status == realm.DeploymentStatusFailed {
+				return realm.ErrDeploymentFailed{deployment.ID, deployment.StatusErrorMessage}
 			}
+			return nil
 		}
 
-		return nil
-	}
+		s.Start()
+		defer s.Stop()
 
-	if err := waitForDeployment(); err != nil {
-		return err
+		return realmClient.WaitForDeploy(remote.GroupID, remote.AppID, deployment.ID, 0)
 	}
 
-	if deployment.Status == realm.DeploymentStatusFailed {
+	if err := waitForDeployment(); err != nil {
+		var deploymentErr realm.ErrDeploymentFailed
+		if !errors.As(err, &deploymentErr) {
+			return err
+		}
 		ui.Print(terminal.NewWarningLog("Deployment failed"))
-		return fmt.Errorf("failed to deploy app: %s", deployment.StatusErrorMessage)
+		return fmt.Errorf("failed to deploy app: %s", deploymentErr.Message)
 	}
 
 	ui.Print(terminal.NewTextLog("Deployment complete"))
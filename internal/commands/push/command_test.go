@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/atlas"
@@ -765,8 +766,8 @@ Successfully pushed app up: eggcorn-abcde
 			}
 
 			t.Run("because of an error", func(t *testing.T) {
-				realmClient.DependenciesStatusFn = func(groupID, appID string) (realm.DependenciesStatus, error) {
-					return realm.DependenciesStatus{}, errors.New("something bad happened")
+				realmClient.WaitForDependenciesFn = func(groupID, appID string, timeout time.Duration, onStatus func(status realm.DependenciesStatus)) error {
+					return errors.New("something bad happened")
 				}
 				out := new(bytes.Buffer)
 				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
@@ -777,8 +778,8 @@ Successfully pushed app up: eggcorn-abcde
 				assert.Equal(t, errors.New("something bad happened"), err)
 			})
 			t.Run("because of an installation problem", func(t *testing.T) {
-				realmClient.DependenciesStatusFn = func(groupID, appID string) (realm.DependenciesStatus, error) {
-					return realm.DependenciesStatus{State: realm.DependenciesStateFailed, Message: "something bad happened"}, nil
+				realmClient.WaitForDependenciesFn = func(groupID, appID string, timeout time.Duration, onStatus func(status realm.DependenciesStatus)) error {
+					return realm.ErrDependenciesFailed{"something bad happened"}
 				}
 				out := new(bytes.Buffer)
 				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
@@ -786,7 +787,7 @@ Successfully pushed app up: eggcorn-abcde
 				cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", IncludeNodeModules: true}}
 
 				err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
-				assert.Equal(t, errors.New("failed to install dependencies: something bad happened"), err)
+				assert.Equal(t, realm.ErrDependenciesFailed{"something bad happened"}, err)
 			})
 		})
 
@@ -794,8 +795,10 @@ Successfully pushed app up: eggcorn-abcde
 			realmClient.ImportDependenciesFn = func(groupID, appID, uploadPath string) error {
 				return nil
 			}
-			realmClient.DependenciesStatusFn = func(groupID, appID string) (realm.DependenciesStatus, error) {
-				return realm.DependenciesStatus{State: realm.DependenciesStateSuccessful}, nil
+			realmClient.WaitForDependenciesFn = func(groupID, appID string, timeout time.Duration, onStatus func(status realm.DependenciesStatus)) error {
+				onStatus(realm.DependenciesStatus{State: realm.DependenciesStateCreated, Message: "installing modules"})
+				onStatus(realm.DependenciesStatus{State: realm.DependenciesStateSuccessful})
+				return nil
 			}
 
 			out := new(bytes.Buffer)
@@ -1596,9 +1599,9 @@ func TestPushCommandDeployDraftAndWait(t *testing.T) {
 			return realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusCreated}, nil
 		}
 
-		t.Run("but fails to get the deployment should return the error", func(t *testing.T) {
-			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
-				return realm.AppDeployment{}, errors.New("something bad happened")
+		t.Run("but fails to wait for the deployment should return the error", func(t *testing.T) {
+			realmClient.WaitForDeployFn = func(groupID, appID, deploymentID string, timeout time.Duration) error {
+				return errors.New("something bad happened")
 			}
 
 			_, ui := mock.NewUI()
@@ -1607,16 +1610,24 @@ func TestPushCommandDeployDraftAndWait(t *testing.T) {
 			assert.Equal(t, errors.New("something bad happened"), err)
 		})
 
-		t.Run("and successfully retrieves the deployment should eventually succeed", func(t *testing.T) {
-			var polls int
+		t.Run("and the deployment fails should return the deployment error", func(t *testing.T) {
+			realmClient.WaitForDeployFn = func(groupID, appID, deploymentID string, timeout time.Duration) error {
+				return realm.ErrDeploymentFailed{deploymentID, "something bad happened"}
+			}
+
+			out, ui := mock.NewUI()
 
-			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
-				status := realm.DeploymentStatusPending
-				if polls > 1 {
-					status = realm.DeploymentStatusSuccessful
-				}
-				polls++
-				return realm.AppDeployment{ID: deploymentID, Status: status}, nil
+			err := deployDraftAndWait(ui, realmClient, appRemote{groupID, appID}, draftID)
+			assert.Equal(t, errors.New("failed to deploy app: something bad happened"), err)
+
+			assert.Equal(t, "Deployment failed\n", out.String())
+		})
+
+		t.Run("and waits for the deployment should eventually succeed", func(t *testing.T) {
+			var capturedDeploymentID string
+			realmClient.WaitForDeployFn = func(groupID, appID, deploymentID string, timeout time.Duration) error {
+				capturedDeploymentID = deploymentID
+				return nil
 			}
 
 			out, ui := mock.NewUI()
@@ -1624,6 +1635,7 @@ func TestPushCommandDeployDraftAndWait(t *testing.T) {
 			err := deployDraftAndWait(ui, realmClient, appRemote{groupID, appID}, draftID)
 			assert.Nil(t, err)
 
+			assert.Equal(t, "id", capturedDeploymentID)
 			assert.Equal(t, "Deployment complete\n", out.String())
 		})
 	})
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 )
@@ -69,13 +71,42 @@ func MarshalJSON(o interface{}) ([]byte, error) {
 	return data, nil
 }
 
+// WriteZipOptions are options to configure writing zip contents
+type WriteZipOptions struct {
+	// Concurrency is the maximum number of files written at once,
+	// defaults to defaultWriteZipConcurrency when left unset
+	Concurrency int
+}
+
+const (
+	defaultWriteZipConcurrency = 8
+)
+
 // WriteZip writes the zip contents to the specified filepath
 func WriteZip(wd string, zipPkg *zip.Reader) error {
+	return WriteZipWithOptions(wd, zipPkg, WriteZipOptions{})
+}
+
+// WriteZipWithOptions writes the zip contents to the specified filepath, writing
+// its files concurrently; should any writes fail, the error reported is the one
+// of the first failed file in the zip contents
+func WriteZipWithOptions(wd string, zipPkg *zip.Reader, opts WriteZipOptions) error {
 	if err := mkdir(wd); err != nil {
 		return err
 	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultWriteZipConcurrency
+	}
+
+	var files []*zip.File
+	var paths []string
 	for _, zipFile := range zipPkg.File {
-		path := filepath.Join(wd, zipFile.Name)
+		path, err := zipFilePath(wd, zipFile.Name)
+		if err != nil {
+			return err
+		}
 
 		if zipFile.FileInfo().IsDir() {
 			if err := mkdir(path); err != nil {
@@ -84,19 +115,98 @@ func WriteZip(wd string, zipPkg *zip.Reader) error {
 			continue
 		}
 
-		data, openErr := zipFile.Open()
-		if openErr != nil {
-			return openErr
-		}
-		defer data.Close()
+		files = append(files, zipFile)
+		paths = append(paths, path)
+	}
 
-		if err := WriteFile(path, zipFile.Mode(), data); err != nil {
+	errs := make([]error, len(files))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = writeZipFile(paths[i], files[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// zipFilePath resolves the path of the zip file within the specified filepath,
+// guarding against zip files whose names would have them written outside of it
+func zipFilePath(wd, name string) (string, error) {
+	if !realm.IsValidExportPath(name) {
+		return "", fmt.Errorf("failed to write zip file %s: path is outside of %s", name, wd)
+	}
+	return filepath.Join(wd, filepath.FromSlash(name)), nil
+}
+
+func writeZipFile(path string, zipFile *zip.File) error {
+	data, openErr := zipFile.Open()
+	if openErr != nil {
+		return openErr
+	}
+	defer data.Close()
+
+	return WriteFile(path, zipFile.Mode(), data)
+}
+
+// WriteZipAtomic writes the zip contents to the specified filepath such that it either
+// holds its previous contents or the complete zip contents, and never a mix of both;
+// the zip contents are written to a temporary directory which then replaces the
+// destination entirely, and is cleaned up if anything fails along the way
+func WriteZipAtomic(wd string, zipPkg *zip.Reader) (err error) {
+	parentDir, name := filepath.Dir(wd), filepath.Base(wd)
+	if err := mkdir(parentDir); err != nil {
+		return err
+	}
+
+	tmpDir, tmpErr := ioutil.TempDir(parentDir, "."+name+"-")
+	if tmpErr != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", tmpErr)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmpDir)
+		}
+	}()
+
+	if err := WriteZip(tmpDir, zipPkg); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(wd); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		return os.Rename(tmpDir, wd)
+	}
+
+	backupDir := tmpDir + "-backup"
+	if err := os.Rename(wd, backupDir); err != nil {
+		return fmt.Errorf("failed to move existing directory at %s: %w", wd, err)
+	}
+
+	if err := os.Rename(tmpDir, wd); err != nil {
+		if restoreErr := os.Rename(backupDir, wd); restoreErr != nil {
+			return fmt.Errorf("failed to restore existing directory at %s: %w", wd, restoreErr)
+		}
+		return fmt.Errorf("failed to move directory into place at %s: %w", wd, err)
+	}
+
+	return os.RemoveAll(backupDir)
+}
+
 func mkdir(path string) error {
 	if err := os.MkdirAll(path, os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create directory at %s: %w", path, err)
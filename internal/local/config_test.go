@@ -0,0 +1,122 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestWriteZipWithOptions(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i < 20; i++ {
+		files[fmt.Sprintf("functions/fn%d.js", i)] = fmt.Sprintf("exports = function(){ return %d; };", i)
+	}
+
+	data := newTestExportZip(t, files)
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	for _, concurrency := range []int{0, 1, 4} {
+		t.Run(fmt.Sprintf("should write the zip contents with a concurrency of %d", concurrency), func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("write-zip")
+			assert.Nil(t, err)
+			defer teardown()
+
+			assert.Nil(t, WriteZipWithOptions(tmpDir, zipPkg, WriteZipOptions{Concurrency: concurrency}))
+			assertDirContents(t, tmpDir, files)
+		})
+	}
+
+	for _, name := range []string{"../evil.js", "functions/../../evil.js", "../app-sibling/evil.js", "/functions/test.js", `..\evil.js`} {
+		t.Run("should fail to write a zip file outside of the directory at "+name, func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("write-zip")
+			assert.Nil(t, err)
+			defer teardown()
+
+			wd := filepath.Join(tmpDir, "app")
+
+			slipData := newTestExportZip(t, map[string]string{name: "exports = 1;"})
+			slipZipPkg, err := zip.NewReader(bytes.NewReader(slipData), int64(len(slipData)))
+			assert.Nil(t, err)
+
+			err = WriteZipWithOptions(wd, slipZipPkg, WriteZipOptions{})
+			assert.Equal(t, fmt.Sprintf("failed to write zip file %s: path is outside of %s", name, wd), err.Error())
+			assertDirContents(t, tmpDir, map[string]string{})
+		})
+	}
+}
+
+func TestWriteZipAtomic(t *testing.T) {
+	data := newTestExportZip(t, map[string]string{
+		"realm_config.json": `{"name":"eggcorn"}`,
+		"functions/test.js": "exports = function(){};",
+	})
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	t.Run("should write the zip contents to a new directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("write-zip-atomic")
+		assert.Nil(t, err)
+		defer teardown()
+
+		wd := filepath.Join(tmpDir, "app")
+		assert.Nil(t, WriteZipAtomic(wd, zipPkg))
+
+		assertDirContents(t, wd, map[string]string{
+			"realm_config.json": `{"name":"eggcorn"}`,
+			"functions/test.js": "exports = function(){};",
+		})
+		assertDirContents(t, tmpDir, map[string]string{
+			"app/realm_config.json": `{"name":"eggcorn"}`,
+			"app/functions/test.js": "exports = function(){};",
+		})
+	})
+
+	t.Run("should replace the contents of an existing directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("write-zip-atomic")
+		assert.Nil(t, err)
+		defer teardown()
+
+		wd := filepath.Join(tmpDir, "app")
+		assert.Nil(t, WriteFile(filepath.Join(wd, "realm_config.json"), 0666, bytes.NewReader([]byte(`{"name":"old"}`))))
+		assert.Nil(t, WriteFile(filepath.Join(wd, "functions", "old.js"), 0666, bytes.NewReader([]byte("exports = 1;"))))
+
+		assert.Nil(t, WriteZipAtomic(wd, zipPkg))
+
+		assertDirContents(t, tmpDir, map[string]string{
+			"app/realm_config.json": `{"name":"eggcorn"}`,
+			"app/functions/test.js": "exports = function(){};",
+		})
+	})
+}
+
+func assertDirContents(t *testing.T, dir string, expected map[string]string) {
+	t.Helper()
+
+	actual := map[string]string{}
+	assert.Nil(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		actual[filepath.ToSlash(rel)] = string(data)
+		return nil
+	}))
+	assert.Equal(t, expected, actual)
+}
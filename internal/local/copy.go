@@ -0,0 +1,86 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// CopyApp copies the source Realm app into the destination group as a new app with the provided name.
+// The source app is exported, a new app is created in the destination group and the exported
+// app data is imported into it once its source-specific identifiers have been replaced
+func CopyApp(realmClient realm.Client, srcGroupID, srcAppID, dstGroupID, name string) (realm.App, error) {
+	appData, err := FetchCurrentConfig(realmClient, srcGroupID, srcAppID)
+	if err != nil {
+		return realm.App{}, err
+	}
+	return copyAppData(realmClient, appData, dstGroupID, name)
+}
+
+// CloneApp copies the source Realm app as a new app with the provided name within the same group,
+// which gives a sandbox of the app to experiment with without affecting it; the clone is created
+// with its own client app id, so the name has to differ from the source app's name
+func CloneApp(realmClient realm.Client, groupID, srcAppID, name string) (realm.App, error) {
+	appData, err := FetchCurrentConfig(realmClient, groupID, srcAppID)
+	if err != nil {
+		return realm.App{}, err
+	}
+
+	srcClientAppID := appData.ID()
+	if name == appData.Name() {
+		return realm.App{}, fmt.Errorf("failed to clone app %s: the clone must have a different name than '%s'", srcClientAppID, name)
+	}
+
+	return copyAppData(realmClient, appData, groupID, name)
+}
+
+// copyAppData creates a new app with the provided name in the group and imports the app data into it,
+// the import is never made into the app the data was exported from and the new app is deleted
+// should the copy fail, so that no orphaned app is left behind
+func copyAppData(realmClient realm.Client, appData AppData, groupID, name string) (realm.App, error) {
+	srcClientAppID := appData.ID()
+
+	app, err := realmClient.CreateApp(groupID, name, realm.AppMeta{
+		Location:        appData.Location(),
+		DeploymentModel: appData.DeploymentModel(),
+		Environment:     appData.Environment(),
+	})
+	if err != nil {
+		return realm.App{}, err
+	}
+	// the client app id is only known once the server has created the app
+	if app.ClientAppID == srcClientAppID {
+		return realm.App{}, deleteCopiedApp(realmClient, app, fmt.Errorf("failed to copy app %s: the new app has the same client app id", srcClientAppID))
+	}
+
+	setAppIdentity(appData, app.ClientAppID, app.Name)
+
+	if err := realmClient.Import(groupID, app.ID, appData); err != nil {
+		return realm.App{}, deleteCopiedApp(realmClient, app, err)
+	}
+	return app, nil
+}
+
+// deleteCopiedApp deletes the app created for a copy that failed with the provided error,
+// which is returned along with the reason the app could not be deleted if so
+func deleteCopiedApp(realmClient realm.Client, app realm.App, err error) error {
+	if deleteErr := realmClient.DeleteApp(app.GroupID, app.ID); deleteErr != nil {
+		return fmt.Errorf("%w (failed to delete the new app %s: %s)", err, app.ClientAppID, deleteErr)
+	}
+	return err
+}
+
+// setAppIdentity replaces the app id and name of the provided app data
+func setAppIdentity(appData AppData, clientAppID, name string) {
+	switch a := appData.(type) {
+	case *AppStitchJSON:
+		a.AppStructureV1.ID = clientAppID
+		a.AppStructureV1.Name = name
+	case *AppConfigJSON:
+		a.AppStructureV1.ID = clientAppID
+		a.AppStructureV1.Name = name
+	case *AppRealmConfigJSON:
+		a.AppStructureV2.ID = clientAppID
+		a.AppStructureV2.Name = name
+	}
+}
@@ -0,0 +1,160 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestCopyApp(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(FileRealmConfig.String())
+	assert.Nil(t, err)
+	_, err = f.Write([]byte(`{"config_version":20210101,"app_id":"source-abcde","name":"source","location":"US-VA","deployment_model":"GLOBAL"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	var createdMeta realm.AppMeta
+	var importedGroupID, importedAppID string
+	var importedData interface{}
+
+	realmClient := mock.RealmClient{}
+	realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+		return "source_20210101.zip", zipPkg, nil
+	}
+	realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+		createdMeta = meta
+		return realm.App{ID: "appID", GroupID: groupID, ClientAppID: name + "-fghij", Name: name}, nil
+	}
+	realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+		importedGroupID = groupID
+		importedAppID = appID
+		importedData = appData
+		return nil
+	}
+
+	app, err := CopyApp(realmClient, "srcGroupID", "srcAppID", "dstGroupID", "copy")
+	assert.Nil(t, err)
+	assert.Equal(t, realm.App{ID: "appID", GroupID: "dstGroupID", ClientAppID: "copy-fghij", Name: "copy"}, app)
+
+	assert.Equal(t, realm.AppMeta{Location: realm.LocationVirginia, DeploymentModel: realm.DeploymentModelGlobal}, createdMeta)
+	assert.Equal(t, "dstGroupID", importedGroupID)
+	assert.Equal(t, "appID", importedAppID)
+
+	appData, ok := importedData.(AppData)
+	assert.True(t, ok, "expected imported data to be app data")
+	assert.Equal(t, "copy-fghij", appData.ID())
+	assert.Equal(t, "copy", appData.Name())
+
+	t.Run("should delete the new app when the import fails", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			deleteErr   error
+			expectedErr error
+		}{
+			{
+				description: "and return the import error",
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "and report the failure to delete it alongside the import error",
+				deleteErr:   errors.New("something worse happened"),
+				expectedErr: errors.New("something bad happened (failed to delete the new app copy-fghij: something worse happened)"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				var deletedGroupID, deletedAppID string
+
+				realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+					return errors.New("something bad happened")
+				}
+				realmClient.DeleteAppFn = func(groupID, appID string) error {
+					deletedGroupID = groupID
+					deletedAppID = appID
+					return tc.deleteErr
+				}
+
+				_, err := CopyApp(realmClient, "srcGroupID", "srcAppID", "dstGroupID", "copy")
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Equal(t, "dstGroupID", deletedGroupID)
+				assert.Equal(t, "appID", deletedAppID)
+			})
+		}
+	})
+}
+
+func TestCloneApp(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(FileRealmConfig.String())
+	assert.Nil(t, err)
+	_, err = f.Write([]byte(`{"config_version":20210101,"app_id":"source-abcde","name":"source","location":"US-VA","deployment_model":"GLOBAL"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	newRealmClient := func(clientAppID string, importedData *interface{}) mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			return "source_20210101.zip", zipPkg, err
+		}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			return realm.App{ID: "cloneID", GroupID: groupID, ClientAppID: clientAppID, Name: name}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			*importedData = appData
+			return nil
+		}
+		return realmClient
+	}
+
+	t.Run("should clone the app within the same group", func(t *testing.T) {
+		var importedData interface{}
+		realmClient := newRealmClient("sandbox-fghij", &importedData)
+
+		app, err := CloneApp(realmClient, "groupID", "srcAppID", "sandbox")
+		assert.Nil(t, err)
+		assert.Equal(t, realm.App{ID: "cloneID", GroupID: "groupID", ClientAppID: "sandbox-fghij", Name: "sandbox"}, app)
+
+		appData, ok := importedData.(AppData)
+		assert.True(t, ok, "expected imported data to be app data")
+		assert.Equal(t, "sandbox-fghij", appData.ID())
+		assert.Equal(t, "sandbox", appData.Name())
+	})
+
+	t.Run("should fail to clone the app with the same name", func(t *testing.T) {
+		var importedData interface{}
+		realmClient := newRealmClient("source-fghij", &importedData)
+
+		_, err := CloneApp(realmClient, "groupID", "srcAppID", "source")
+		assert.Equal(t, "failed to clone app source-abcde: the clone must have a different name than 'source'", err.Error())
+		assert.Nil(t, importedData)
+	})
+
+	t.Run("should fail and delete the clone when it has the same client app id", func(t *testing.T) {
+		var importedData interface{}
+		realmClient := newRealmClient("source-abcde", &importedData)
+
+		var deletedGroupID, deletedAppID string
+		realmClient.DeleteAppFn = func(groupID, appID string) error {
+			deletedGroupID = groupID
+			deletedAppID = appID
+			return nil
+		}
+
+		_, err := CloneApp(realmClient, "groupID", "srcAppID", "sandbox")
+		assert.Equal(t, "failed to copy app source-abcde: the new app has the same client app id", err.Error())
+		assert.Nil(t, importedData)
+		assert.Equal(t, "groupID", deletedGroupID)
+		assert.Equal(t, "cloneID", deletedAppID)
+	})
+}
@@ -0,0 +1,325 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	diffAppNameField       = "name"
+	diffAppNamePlaceholder = "app_name"
+
+	unifiedDiffContext  = 3
+	unifiedDiffCurrent  = "current"
+	unifiedDiffProposed = "proposed"
+)
+
+// DiffLocal computes the differences between two Realm app export archives
+// without contacting the server, each changed file is reported as a separate
+// diff in the same format as the server's import diffs
+func DiffLocal(oldZip, newZip []byte) ([]string, error) {
+	oldFiles, oldErr := readExportFiles(oldZip)
+	if oldErr != nil {
+		return nil, fmt.Errorf("failed to read old export: %w", oldErr)
+	}
+
+	newFiles, newErr := readExportFiles(newZip)
+	if newErr != nil {
+		return nil, fmt.Errorf("failed to read new export: %w", newErr)
+	}
+
+	return diffExportFiles(oldFiles, newFiles), nil
+}
+
+// DiffApps computes the differences between two live Realm apps (e.g. a staging app and its
+// production counterpart), both apps are exported and diffed locally the same way as DiffLocal;
+// their environment specific identifiers are normalized beforehand so they are not reported
+func DiffApps(realmClient realm.Client, srcGroupID, srcAppID, dstGroupID, dstAppID string) ([]string, error) {
+	req := realm.ExportRequest{UsePlaceholders: true, OmitVolatileFields: true}
+
+	_, srcZip, srcErr := realmClient.ExportBytes(srcGroupID, srcAppID, req)
+	if srcErr != nil {
+		return nil, fmt.Errorf("failed to export source app: %w", srcErr)
+	}
+
+	_, dstZip, dstErr := realmClient.ExportBytes(dstGroupID, dstAppID, req)
+	if dstErr != nil {
+		return nil, fmt.Errorf("failed to export destination app: %w", dstErr)
+	}
+
+	srcFiles, err := readExportFiles(srcZip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source app export: %w", err)
+	}
+
+	dstFiles, err := readExportFiles(dstZip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination app export: %w", err)
+	}
+
+	normalizeAppName(srcFiles)
+	normalizeAppName(dstFiles)
+
+	return diffExportFiles(srcFiles, dstFiles), nil
+}
+
+// DiffUnified computes the differences between the app's current config and the provided
+// app data as a unified diff, which can be viewed with any diff tool; both configs are compared
+// as canonical json documents in full, the same way a replace strategy import would apply them
+func DiffUnified(realmClient realm.Client, groupID, appID string, appData interface{}) (string, error) {
+	current, err := FetchCurrentConfig(realmClient, groupID, appID)
+	if err != nil {
+		return "", err
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+
+	proposedJSON, err := json.Marshal(appData)
+	if err != nil {
+		return "", err
+	}
+
+	return unifiedDiff(
+		unifiedDiffCurrent,
+		unifiedDiffProposed,
+		splitLines(string(realm.CanonicalJSON(currentJSON))),
+		splitLines(string(realm.CanonicalJSON(proposedJSON))),
+	), nil
+}
+
+// unifiedDiff formats the differences between the old and new lines as a unified diff
+// with hunks of changes surrounded by unifiedDiffContext lines of context,
+// no diff is returned when the lines are the same
+func unifiedDiff(oldName, newName string, oldLines, newLines []string) string {
+	lines := diffLines(oldLines, newLines)
+
+	var changes []int
+	for i, line := range lines {
+		if line[0] != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var diff strings.Builder
+	diff.WriteString("--- " + oldName + "\n")
+	diff.WriteString("+++ " + newName + "\n")
+
+	for i := 0; i < len(changes); {
+		// group the changes whose context lines would overlap into the same hunk
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*unifiedDiffContext {
+			j++
+		}
+
+		start := changes[i] - unifiedDiffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changes[j] + unifiedDiffContext + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		var oldStart, newStart int
+		for _, line := range lines[:start] {
+			if line[0] != '+' {
+				oldStart++
+			}
+			if line[0] != '-' {
+				newStart++
+			}
+		}
+
+		var oldCount, newCount int
+		for _, line := range lines[start:end] {
+			if line[0] != '+' {
+				oldCount++
+			}
+			if line[0] != '-' {
+				newCount++
+			}
+		}
+
+		// hunk ranges are 1-based, except for an empty range which
+		// refers to the line right before it
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+
+		diff.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount))
+		for _, line := range lines[start:end] {
+			diff.WriteString(line + "\n")
+		}
+
+		i = j + 1
+	}
+	return diff.String()
+}
+
+// normalizeAppName replaces the app name of the export's app config file with a placeholder,
+// as the app id is already replaced by exporting with placeholders
+func normalizeAppName(files map[string]string) {
+	for _, file := range allConfigFiles {
+		path := file.String()
+
+		data, ok := files[path]
+		if !ok {
+			continue
+		}
+
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &config); err != nil {
+			continue
+		}
+		if _, ok := config[diffAppNameField].(string); !ok {
+			continue
+		}
+		config[diffAppNameField] = realm.Placeholder(diffAppNamePlaceholder)
+
+		normalized, err := MarshalJSON(config)
+		if err != nil {
+			continue
+		}
+		files[path] = string(normalized)
+	}
+}
+
+// diffExportFiles computes the differences between the files of two export archives keyed by their path
+func diffExportFiles(oldFiles, newFiles map[string]string) []string {
+	paths := make([]string, 0, len(oldFiles)+len(newFiles))
+	for path := range oldFiles {
+		paths = append(paths, path)
+	}
+	for path := range newFiles {
+		if _, ok := oldFiles[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var diffs []string
+	for _, path := range paths {
+		oldData, inOld := oldFiles[path]
+		newData, inNew := newFiles[path]
+
+		if inOld && inNew && oldData == newData {
+			continue
+		}
+
+		var diff strings.Builder
+		if inOld {
+			diff.WriteString("--- " + path + "\n")
+		}
+		if inNew {
+			diff.WriteString("+++ " + path + "\n")
+		}
+		for _, line := range diffLines(splitLines(oldData), splitLines(newData)) {
+			diff.WriteString(line + "\n")
+		}
+		diffs = append(diffs, strings.TrimSuffix(diff.String(), "\n"))
+	}
+	return diffs
+}
+
+// readExportFiles reads the files of an export archive keyed by their path,
+// json files are normalized so that only structural changes are reported
+func readExportFiles(data []byte) (map[string]string, error) {
+	zipPkg, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(zipPkg.File))
+	for _, zipFile := range zipPkg.File {
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+
+		r, openErr := zipFile.Open()
+		if openErr != nil {
+			return nil, openErr
+		}
+
+		contents, readErr := ioutil.ReadAll(r)
+		r.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		path := filepath.ToSlash(filepath.Clean(zipFile.Name))
+		if filepath.Ext(path) == extJSON {
+			contents = realm.CanonicalJSON(contents)
+		}
+		files[path] = string(contents)
+	}
+	return files, nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the line by line differences between the old and new lines,
+// based on their longest common subsequence
+func diffLines(oldLines, newLines []string) []string {
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(oldLines)+len(newLines))
+
+	var i, j int
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			lines = append(lines, " "+oldLines[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+oldLines[i])
+			i++
+		default:
+			lines = append(lines, "+"+newLines[j])
+			j++
+		}
+	}
+	for ; i < len(oldLines); i++ {
+		lines = append(lines, "-"+oldLines[i])
+	}
+	for ; j < len(newLines); j++ {
+		lines = append(lines, "+"+newLines[j])
+	}
+	return lines
+}
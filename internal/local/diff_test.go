@@ -0,0 +1,219 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDiffLocal(t *testing.T) {
+	oldZip := newTestExportZip(t, map[string]string{
+		"realm_config.json":           `{"name":"eggcorn","location":"US-VA"}`,
+		"functions/test.js":           "exports = function(){\n  return 1;\n};\n",
+		"functions/removed.js":        "exports = function(){};\n",
+		"graphql/custom_resolvers/.g": "",
+	})
+
+	t.Run("should report no diffs for identical exports", func(t *testing.T) {
+		diffs, err := DiffLocal(oldZip, oldZip)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(diffs))
+	})
+
+	t.Run("should ignore json formatting and key order", func(t *testing.T) {
+		newZip := newTestExportZip(t, map[string]string{
+			"realm_config.json":           "{\n  \"location\": \"US-VA\",\n  \"name\": \"eggcorn\"\n}",
+			"functions/test.js":           "exports = function(){\n  return 1;\n};\n",
+			"functions/removed.js":        "exports = function(){};\n",
+			"graphql/custom_resolvers/.g": "",
+		})
+
+		diffs, err := DiffLocal(oldZip, newZip)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(diffs))
+	})
+
+	t.Run("should report added, removed, and modified files", func(t *testing.T) {
+		newZip := newTestExportZip(t, map[string]string{
+			"realm_config.json":           `{"name":"eggcorn","location":"IE"}`,
+			"functions/test.js":           "exports = function(){\n  return 2;\n};\n",
+			"functions/added.js":          "exports = function(){};\n",
+			"graphql/custom_resolvers/.g": "",
+		})
+
+		diffs, err := DiffLocal(oldZip, newZip)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"+++ functions/added.js\n+exports = function(){};",
+			"--- functions/removed.js\n-exports = function(){};",
+			"--- functions/test.js\n+++ functions/test.js\n exports = function(){\n-  return 1;\n+  return 2;\n };",
+			`--- realm_config.json
++++ realm_config.json
+ {
+-    "location": "US-VA",
++    "location": "IE",
+     "name": "eggcorn"
+ }`,
+		}, diffs)
+	})
+
+	t.Run("should fail with an invalid export", func(t *testing.T) {
+		_, err := DiffLocal([]byte("not a zip"), oldZip)
+		assert.Equal(t, "failed to read old export: zip: not a valid zip file", err.Error())
+	})
+}
+
+func TestDiffApps(t *testing.T) {
+	exports := map[string][]byte{
+		"stagingAppID": newTestExportZip(t, map[string]string{
+			"realm_config.json": `{"app_id":"{{app_id}}","name":"staging","location":"US-VA"}`,
+			"functions/test.js": "exports = function(){\n  return 1;\n};\n",
+		}),
+		"prodAppID": newTestExportZip(t, map[string]string{
+			"realm_config.json": `{"app_id":"{{app_id}}","name":"prod","location":"US-VA"}`,
+			"functions/test.js": "exports = function(){\n  return 2;\n};\n",
+		}),
+	}
+
+	var exportReqs []realm.ExportRequest
+
+	realmClient := mock.RealmClient{}
+	realmClient.ExportBytesFn = func(groupID, appID string, req realm.ExportRequest) (string, []byte, error) {
+		exportReqs = append(exportReqs, req)
+		return appID + "_20210101.zip", exports[appID], nil
+	}
+
+	t.Run("should report the differences without the environment specific identifiers", func(t *testing.T) {
+		diffs, err := DiffApps(realmClient, "stagingGroupID", "stagingAppID", "prodGroupID", "prodAppID")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"--- functions/test.js\n+++ functions/test.js\n exports = function(){\n-  return 1;\n+  return 2;\n };",
+		}, diffs)
+
+		for _, req := range exportReqs {
+			assert.True(t, req.UsePlaceholders, "expected export to use placeholders")
+			assert.True(t, req.OmitVolatileFields, "expected export to omit volatile fields")
+		}
+	})
+
+	t.Run("should fail when an app fails to export", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.ExportBytesFn = func(groupID, appID string, req realm.ExportRequest) (string, []byte, error) {
+			return "", nil, errors.New("something bad happened")
+		}
+
+		_, err := DiffApps(realmClient, "stagingGroupID", "stagingAppID", "prodGroupID", "prodAppID")
+		assert.Equal(t, "failed to export source app: something bad happened", err.Error())
+	})
+}
+
+func TestDiffUnified(t *testing.T) {
+	zipData := newTestExportZip(t, map[string]string{
+		"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+	})
+
+	realmClient := mock.RealmClient{}
+	realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+		zipPkg, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+		return "eggcorn_20210101.zip", zipPkg, err
+	}
+
+	t.Run("should report no diff for the current app data", func(t *testing.T) {
+		current, err := FetchCurrentConfig(realmClient, "groupID", "appID")
+		assert.Nil(t, err)
+
+		diff, err := DiffUnified(realmClient, "groupID", "appID", current)
+		assert.Nil(t, err)
+		assert.Equal(t, "", diff)
+	})
+
+	t.Run("should report the diff of the proposed app data", func(t *testing.T) {
+		current, err := FetchCurrentConfig(realmClient, "groupID", "appID")
+		assert.Nil(t, err)
+
+		proposed, ok := current.(*AppRealmConfigJSON)
+		assert.True(t, ok, "expected app data to be a realm config")
+		proposed.AppStructureV2.Location = realm.LocationIreland
+
+		diff, err := DiffUnified(realmClient, "groupID", "appID", proposed)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(diff, "--- current\n+++ proposed\n@@ "), "expected a unified diff but got: %s", diff)
+		assert.True(t, strings.Contains(diff, "\n-    \"location\": \"US-VA\",\n+    \"location\": \"IE\",\n"), "expected the location to differ but got: %s", diff)
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldLines := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+
+	t.Run("should report no diff for the same lines", func(t *testing.T) {
+		assert.Equal(t, "", unifiedDiff("old", "new", oldLines, oldLines))
+	})
+
+	t.Run("should report the changes in separate hunks with context", func(t *testing.T) {
+		newLines := []string{"1", "two", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13"}
+
+		assert.Equal(t, `--- old
++++ new
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`, unifiedDiff("old", "new", oldLines, newLines))
+	})
+
+	t.Run("should merge changes with overlapping context into one hunk", func(t *testing.T) {
+		newLines := []string{"1", "2", "three", "4", "5", "6", "7", "eight", "9", "10", "11", "12"}
+
+		assert.Equal(t, `--- old
++++ new
+@@ -1,11 +1,11 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+ 7
+-8
++eight
+ 9
+ 10
+ 11
+`, unifiedDiff("old", "new", oldLines, newLines))
+	})
+
+	t.Run("should report an empty old range for a new file", func(t *testing.T) {
+		assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+1\n+2\n", unifiedDiff("old", "new", nil, []string{"1", "2"}))
+	})
+}
+
+func newTestExportZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range files {
+		f, err := w.Create(name)
+		assert.Nil(t, err)
+		_, err = f.Write([]byte(contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,40 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	tempDirExportApp = "realm-cli-export-app"
+)
+
+// FetchCurrentConfig fetches the app config currently in effect for the Realm app by exporting it,
+// which is useful to confirm the outcome of an import using a merge strategy
+func FetchCurrentConfig(realmClient realm.Client, groupID, appID string) (AppData, error) {
+	_, zipPkg, err := realmClient.Export(groupID, appID, realm.ExportRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", tempDirExportApp)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := WriteZip(dir, zipPkg); err != nil {
+		return nil, err
+	}
+
+	app, err := LoadApp(dir)
+	if err != nil {
+		return nil, err
+	}
+	if app.AppData == nil {
+		return nil, errFailedToParseAppConfig(dir)
+	}
+	return app.AppData, nil
+}
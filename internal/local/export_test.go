@@ -0,0 +1,49 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestCurrentAppData(t *testing.T) {
+	t.Run("should return the exported app data", func(t *testing.T) {
+		data := newTestExportZip(t, map[string]string{
+			FileRealmConfig.String(): `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+		})
+
+		var exportedGroupID, exportedAppID string
+
+		realmClient := mock.RealmClient{}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			exportedGroupID = groupID
+			exportedAppID = appID
+			zipPkg, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			return "eggcorn_20210101.zip", zipPkg, err
+		}
+
+		appData, err := FetchCurrentConfig(realmClient, "groupID", "appID")
+		assert.Nil(t, err)
+		assert.Equal(t, "groupID", exportedGroupID)
+		assert.Equal(t, "appID", exportedAppID)
+
+		assert.Equal(t, "eggcorn-abcde", appData.ID())
+		assert.Equal(t, "eggcorn", appData.Name())
+		assert.Equal(t, realm.LocationVirginia, appData.Location())
+	})
+
+	t.Run("should fail when the export fails", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return "", nil, errors.New("something bad happened")
+		}
+
+		_, err := FetchCurrentConfig(realmClient, "groupID", "appID")
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
@@ -0,0 +1,69 @@
+package local
+
+import (
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// ImportOrCreate imports the app data into the group's existing app, creating the app first
+// if it does not exist yet. The existing app is the one with the app data's client app id,
+// or the one with the provided name (defaulting to the app data's name) when the app data
+// has none. Should another run create the app at the same time, it is looked up again once
+// its creation fails so that both runs import into the same app
+func ImportOrCreate(realmClient realm.Client, groupID, name string, appData AppData, opts realm.ImportOptions) (realm.App, error) {
+	if name == "" {
+		name = appData.Name()
+	}
+
+	app, found, err := findImportApp(realmClient, groupID, appData.ID(), name)
+	if err != nil {
+		return realm.App{}, err
+	}
+
+	if !found {
+		created, createErr := realmClient.CreateApp(groupID, name, realm.AppMeta{
+			Location:        appData.Location(),
+			DeploymentModel: appData.DeploymentModel(),
+			Environment:     appData.Environment(),
+		})
+		if createErr != nil {
+			raced, racedFound, err := findImportApp(realmClient, groupID, "", name)
+			if err != nil || !racedFound {
+				return realm.App{}, createErr
+			}
+			created = raced
+		}
+		app = created
+	}
+
+	setAppIdentity(appData, app.ClientAppID, app.Name)
+
+	if err := realmClient.ImportWithOptions(groupID, app.ID, appData, opts); err != nil {
+		return realm.App{}, err
+	}
+	return app, nil
+}
+
+// findImportApp finds the group's app with the client app id if provided, otherwise with the name
+func findImportApp(realmClient realm.Client, groupID, clientAppID, name string) (realm.App, bool, error) {
+	if clientAppID != "" {
+		app, err := realmClient.FindAppByClientAppID(groupID, clientAppID)
+		if err != nil {
+			if _, ok := err.(realm.ErrAppNotFound); ok {
+				return realm.App{}, false, nil
+			}
+			return realm.App{}, false, err
+		}
+		return app, true, nil
+	}
+
+	apps, err := realmClient.FindApps(realm.AppFilter{GroupID: groupID})
+	if err != nil {
+		return realm.App{}, false, err
+	}
+	for _, app := range apps {
+		if app.Name == name {
+			return app, true, nil
+		}
+	}
+	return realm.App{}, false, nil
+}
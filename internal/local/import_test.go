@@ -0,0 +1,120 @@
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestImportOrCreate(t *testing.T) {
+	newAppData := func(clientAppID string) AppData {
+		appData := NewAppRealmConfigJSON("eggcorn", realm.AppMeta{
+			Location:        realm.LocationVirginia,
+			DeploymentModel: realm.DeploymentModelGlobal,
+		})
+		appData.AppStructureV2.ID = clientAppID
+		return &appData
+	}
+
+	existing := realm.App{ID: "appID", GroupID: "groupID", ClientAppID: "eggcorn-abcde", Name: "eggcorn"}
+
+	t.Run("should import into the app with the client app id", func(t *testing.T) {
+		var importedAppID string
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppByClientAppIDFn = func(groupID, clientAppID string) (realm.App, error) {
+			assert.Equal(t, "eggcorn-abcde", clientAppID)
+			return existing, nil
+		}
+		realmClient.ImportWithOptionsFn = func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+			importedAppID = appID
+			return nil
+		}
+
+		app, err := ImportOrCreate(realmClient, "groupID", "", newAppData("eggcorn-abcde"), realm.ImportOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, existing, app)
+		assert.Equal(t, "appID", importedAppID)
+	})
+
+	t.Run("should import into the app with the name", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "otherAppID", Name: "other"}, existing}, nil
+		}
+		realmClient.ImportWithOptionsFn = func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+			return nil
+		}
+
+		app, err := ImportOrCreate(realmClient, "groupID", "", newAppData(""), realm.ImportOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, existing, app)
+	})
+
+	t.Run("should create the app if it does not exist and import into it", func(t *testing.T) {
+		var createdName string
+		var importedData interface{}
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppByClientAppIDFn = func(groupID, clientAppID string) (realm.App, error) {
+			return realm.App{}, realm.ErrAppNotFound{clientAppID}
+		}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			createdName = name
+			return realm.App{ID: "newAppID", GroupID: groupID, ClientAppID: name + "-fghij", Name: name}, nil
+		}
+		realmClient.ImportWithOptionsFn = func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+			importedData = appData
+			return nil
+		}
+
+		app, err := ImportOrCreate(realmClient, "groupID", "renamed", newAppData("eggcorn-abcde"), realm.ImportOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, realm.App{ID: "newAppID", GroupID: "groupID", ClientAppID: "renamed-fghij", Name: "renamed"}, app)
+		assert.Equal(t, "renamed", createdName)
+
+		appData, ok := importedData.(AppData)
+		assert.True(t, ok, "expected imported data to be app data")
+		assert.Equal(t, "renamed-fghij", appData.ID())
+		assert.Equal(t, "renamed", appData.Name())
+	})
+
+	t.Run("should import into the app created by a concurrent run", func(t *testing.T) {
+		var findCalls int
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			findCalls++
+			if findCalls == 1 {
+				return nil, nil
+			}
+			return []realm.App{existing}, nil
+		}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			return realm.App{}, errors.New("app name already exists")
+		}
+		realmClient.ImportWithOptionsFn = func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+			return nil
+		}
+
+		app, err := ImportOrCreate(realmClient, "groupID", "", newAppData(""), realm.ImportOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, existing, app)
+	})
+
+	t.Run("should fail with the create error if the app still does not exist", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return nil, nil
+		}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			return realm.App{}, errors.New("something bad happened")
+		}
+
+		_, err := ImportOrCreate(realmClient, "groupID", "", newAppData(""), realm.ImportOptions{})
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
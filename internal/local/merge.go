@@ -0,0 +1,48 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergeConfigs deep merges the overlay app config payload into the base one,
+// producing a single payload that can be imported; this lets environment specific
+// overlays be kept separate from the base config they apply to.
+//
+// Objects are merged key by key at any depth, with the overlay's value winning for
+// keys present in both. Any other value, including arrays, is replaced as a whole by
+// the overlay's value, so an overlay array never gets its elements merged or appended
+// to the base array. A null overlay value replaces the base value with null.
+func MergeConfigs(base, overlay []byte) ([]byte, error) {
+	var baseConfig interface{}
+	if err := json.Unmarshal(base, &baseConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse base config: %w", err)
+	}
+
+	var overlayConfig interface{}
+	if err := json.Unmarshal(overlay, &overlayConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay config: %w", err)
+	}
+
+	return json.Marshal(mergeConfigValues(baseConfig, overlayConfig))
+}
+
+func mergeConfigValues(base, overlay interface{}) interface{} {
+	baseObj, baseOK := base.(map[string]interface{})
+	overlayObj, overlayOK := overlay.(map[string]interface{})
+	if !baseOK || !overlayOK {
+		return overlay
+	}
+
+	merged := make(map[string]interface{}, len(baseObj)+len(overlayObj))
+	for key, value := range baseObj {
+		merged[key] = value
+	}
+	for key, value := range overlayObj {
+		if baseValue, ok := merged[key]; ok {
+			value = mergeConfigValues(baseValue, value)
+		}
+		merged[key] = value
+	}
+	return merged
+}
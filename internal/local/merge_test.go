@@ -0,0 +1,57 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		base        string
+		overlay     string
+		expected    string
+	}{
+		{
+			description: "should deep merge objects with the overlay winning",
+			base:        `{"name":"eggcorn","location":"US-VA","sync":{"enabled":false,"development_mode_enabled":true}}`,
+			overlay:     `{"location":"IE","sync":{"enabled":true}}`,
+			expected:    `{"location":"IE","name":"eggcorn","sync":{"development_mode_enabled":true,"enabled":true}}`,
+		},
+		{
+			description: "should replace arrays as a whole",
+			base:        `{"allowed_request_origins":["http://localhost:8080","http://localhost:8081"]}`,
+			overlay:     `{"allowed_request_origins":["https://eggcorn.com"]}`,
+			expected:    `{"allowed_request_origins":["https://eggcorn.com"]}`,
+		},
+		{
+			description: "should replace values of a different type and nulls",
+			base:        `{"custom_user_data":{"enabled":true},"environment":"development"}`,
+			overlay:     `{"custom_user_data":false,"environment":null}`,
+			expected:    `{"custom_user_data":false,"environment":null}`,
+		},
+		{
+			description: "should keep the base config with an empty overlay",
+			base:        `{"name":"eggcorn"}`,
+			overlay:     `{}`,
+			expected:    `{"name":"eggcorn"}`,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			merged, err := MergeConfigs([]byte(tc.base), []byte(tc.overlay))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, string(merged))
+		})
+	}
+
+	t.Run("should fail with an invalid base config", func(t *testing.T) {
+		_, err := MergeConfigs([]byte("not json"), []byte(`{}`))
+		assert.Equal(t, "failed to parse base config: invalid character 'o' in literal null (expecting 'u')", err.Error())
+	})
+
+	t.Run("should fail with an invalid overlay config", func(t *testing.T) {
+		_, err := MergeConfigs([]byte(`{}`), []byte("not json"))
+		assert.Equal(t, "failed to parse overlay config: invalid character 'o' in literal null (expecting 'u')", err.Error())
+	})
+}
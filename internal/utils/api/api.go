@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,12 +10,17 @@ import (
 // set of supported api header keys
 const (
 	HeaderAccept                  = "Accept"
+	HeaderAcceptRanges            = "Accept-Ranges"
 	HeaderCacheControl            = "Cache-Control"
 	HeaderContentDisposition      = "Content-Disposition"
 	HeaderContentEncoding         = "Content-Encoding"
 	HeaderContentLanguage         = "Content-Language"
 	HeaderContentType             = "Content-Type"
 	HeaderAuthorization           = "Authorization"
+	HeaderDigest                  = "Digest"
+	HeaderETag                    = "ETag"
+	HeaderIfNoneMatch             = "If-None-Match"
+	HeaderRange                   = "Range"
 	HeaderWebsiteRedirectLocation = "Website-Redirect-Location"
 )
 
@@ -27,10 +33,13 @@ const (
 type RequestOptions struct {
 	Body           io.Reader
 	ContentType    string
+	Context        context.Context
+	Headers        map[string]string
 	NoAuth         bool
 	PreventRefresh bool
 	Query          map[string]string
 	RefreshAuth    bool
+	Retry          bool
 }
 
 // IncludeQuery includes the query with the http request
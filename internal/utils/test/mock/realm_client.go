@@ -2,46 +2,87 @@ package mock
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"io"
+	"net/http"
+	"time"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/api"
 )
 
 // RealmClient is a mocked Realm client
 type RealmClient struct {
 	realm.Client
 
-	AuthenticateFn func(publicAPIKey, privateAPIKey string) (realm.Session, error)
-	AuthProfileFn  func() (realm.AuthProfile, error)
-
-	DiffFn   func(groupID, appID string, appData interface{}) ([]string, error)
-	ExportFn func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error)
-	ImportFn func(groupID, appID string, appData interface{}) error
+	AuthenticateFn      func(publicAPIKey, privateAPIKey string) (realm.Session, error)
+	SaveSessionFn       func(session realm.Session) error
+	ClearSessionFn      func() error
+	AuthProfileFn       func() (realm.AuthProfile, error)
+	RotateCredentialsFn func(publicAPIKey, privateAPIKey string) error
+
+	DiffFn               func(groupID, appID string, appData interface{}) ([]string, error)
+	DiffDeploymentFn     func(groupID, appID, deploymentID string, appData interface{}) ([]string, error)
+	DiffWithWarningsFn   func(groupID, appID string, appData interface{}) (realm.DiffResult, error)
+	DiffGroupedFn        func(groupID, appID string, appData interface{}) (map[string][]string, error)
+	DestructiveChangesFn func(groupID, appID string, appData []byte, strategy string) ([]string, error)
+	ExportFn             func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error)
+	ExportBytesFn        func(groupID, appID string, req realm.ExportRequest) (string, []byte, error)
+	ExportFilesFn        func(groupID, appID string, req realm.ExportRequest) (map[string][]byte, string, error)
+	ExportEachFileFn     func(groupID, appID string, req realm.ExportRequest, fn realm.ExportFileFunc) error
+	ExportToTarGzFn      func(groupID, appID string, req realm.ExportRequest, w io.Writer) error
+	ExportWithManifestFn func(groupID, appID string, req realm.ExportRequest) (realm.ExportManifest, []byte, error)
+	ImportFn             func(groupID, appID string, appData interface{}) error
+
+	ImportWithOptionsFn func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error
+	ImportManyFn        func(groupID string, appData map[string]interface{}, opts realm.ImportOptions) error
+	ImportAndVerifyFn   func(groupID, appID string, appData interface{}, opts realm.ImportOptions) error
+	ImportYAMLFn        func(groupID, appID string, data []byte, opts realm.ImportOptions) error
 
 	ExportDependenciesFn        func(groupID, appID string) (string, io.ReadCloser, error)
 	ExportDependenciesArchiveFn func(groupID, appID string) (string, io.ReadCloser, error)
 	ImportDependenciesFn        func(groupID, appID, uploadPath string) error
 	DiffDependenciesFn          func(groupID, appID, uploadPath string) (realm.DependenciesDiff, error)
+	DependenciesFn              func(groupID, appID string) ([]realm.DependencyData, error)
 	DependenciesStatusFn        func(groupID, appID string) (realm.DependenciesStatus, error)
-
-	CreateAppFn      func(groupID, name string, meta realm.AppMeta) (realm.App, error)
-	DeleteAppFn      func(groupID, appID string) error
-	FindAppFn        func(groupID, appID string) (realm.App, error)
-	FindAppsFn       func(filter realm.AppFilter) ([]realm.App, error)
-	AppDescriptionFn func(groupID, appID string) (realm.AppDescription, error)
-
-	CreateDraftFn  func(groupID, appID string) (realm.AppDraft, error)
-	DiffDraftFn    func(groupID, appID, draftID string) (realm.AppDraftDiff, error)
-	DiscardDraftFn func(groupID, appID, draftID string) error
-	DraftFn        func(groupID, appID string) (realm.AppDraft, error)
-
-	DeployDraftFn func(groupID, appID, draftID string) (realm.AppDeployment, error)
-	DeploymentFn  func(groupID, appID, deploymentID string) (realm.AppDeployment, error)
+	WaitForDependenciesFn       func(groupID, appID string, timeout time.Duration, onStatus func(status realm.DependenciesStatus)) error
+
+	CreateAppFn            func(groupID, name string, meta realm.AppMeta) (realm.App, error)
+	DeleteAppFn            func(groupID, appID string) error
+	PreDeleteCheckFn       func(groupID, appID string) ([]string, error)
+	DisableAppFn           func(groupID, appID string) error
+	EnableAppFn            func(groupID, appID string) error
+	FindAppFn              func(groupID, appID string) (realm.App, error)
+	FindAppsFn             func(filter realm.AppFilter) ([]realm.App, error)
+	ListAllAppsFn          func() (map[string][]realm.App, error)
+	FindAppByClientAppIDFn func(groupID, clientAppID string) (realm.App, error)
+	AppDescriptionFn       func(groupID, appID string) (realm.AppDescription, error)
+	FetchResourceFn        func(groupID, appID, resourcePath string) (json.RawMessage, error)
+
+	CreateDraftFn        func(groupID, appID string) (realm.AppDraft, error)
+	DiffDraftFn          func(groupID, appID, draftID string) (realm.AppDraftDiff, error)
+	DiscardDraftFn       func(groupID, appID, draftID string) error
+	DraftFn              func(groupID, appID string) (realm.AppDraft, error)
+	DeployConfigFn       func(groupID, appID string) (realm.DeployConfig, error)
+	UpdateDeployConfigFn func(groupID, appID string, config realm.DeployConfig) error
+
+	DeployDraftFn   func(groupID, appID, draftID string) (realm.AppDeployment, error)
+	DeploymentFn    func(groupID, appID, deploymentID string) (realm.AppDeployment, error)
+	WaitForDeployFn func(groupID, appID, deploymentID string, timeout time.Duration) error
 
 	SecretsFn      func(groupID, appID string) ([]realm.Secret, error)
 	CreateSecretFn func(groupID, appID, name, value string) (realm.Secret, error)
 	DeleteSecretFn func(groupID, appID, secretID string) error
 	UpdateSecretFn func(groupID, appID, secretID, name, value string) error
+	ValuesFn       func(groupID, appID string) ([]realm.Value, error)
+	FindValueFn    func(groupID, appID, name string) (realm.Value, error)
+
+	AuthProvidersFn       func(groupID, appID string) ([]realm.AuthProvider, error)
+	DisableAuthProviderFn func(groupID, appID, providerID string) error
+	EnableAuthProviderFn  func(groupID, appID, providerID string) error
+
+	CustomUserDataFn       func(groupID, appID string) (realm.CustomUserDataConfig, error)
+	UpdateCustomUserDataFn func(groupID, appID string, config realm.CustomUserDataConfig) error
 
 	CreateAPIKeyFn      func(groupID, appID, apiKeyName string) (realm.APIKey, error)
 	CreateUserFn        func(groupID, appID, email, password string) (realm.User, error)
@@ -50,6 +91,8 @@ type RealmClient struct {
 	EnableUserFn        func(groupID, appID, userID string) error
 	FindUsersFn         func(groupID, appID string, filter realm.UserFilter) ([]realm.User, error)
 	RevokeUserSessionFn func(groupID, appID, userID string) error
+	ConfirmUserFn       func(groupID, appID, email string) error
+	ResetUserPasswordFn func(groupID, appID, email, password string) error
 
 	HostingAssetsFn                func(groupID, appID string) ([]realm.HostingAsset, error)
 	HostingAssetUploadFn           func(groupID, appID, rootDir string, asset realm.HostingAsset) error
@@ -58,22 +101,49 @@ type RealmClient struct {
 	HostingCacheInvalidateFn       func(groupID, appID, path string) error
 
 	FunctionsFn               func(groupID, appID string) ([]realm.Function, error)
+	ExportFunctionFn          func(groupID, appID, functionName string) (string, error)
+	UpdateFunctionFn          func(groupID, appID, functionName, source string) error
 	AppDebugExecuteFunctionFn func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error)
 
-	LogsFn func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error)
-
-	SchemaModelsFn func(groupID, appID, language string) ([]realm.SchemaModel, error)
+	LogsFn               func(groupID, appID string, opts realm.LogsOptions) (realm.Logs, error)
+	LogsPageFn           func(groupID, appID string, opts realm.LogsOptions, cursor realm.LogsCursor) (realm.Logs, realm.LogsCursor, error)
+	TriggerExecutionsFn  func(groupID, appID, triggerID string, opts realm.TriggerExecutionsOptions) ([]realm.TriggerExecution, error)
+	LogForwardersFn      func(groupID, appID string) ([]realm.LogForwarder, error)
+	UpsertLogForwarderFn func(groupID, appID string, forwarder realm.LogForwarder) (realm.LogForwarder, error)
+	SchemasFn            func(groupID, appID string) ([]realm.Schema, error)
+	FindSchemaFn         func(groupID, appID, serviceID, database, collection string) (realm.Schema, error)
+	UpsertSchemaFn       func(groupID, appID, serviceID, database, collection string, schema json.RawMessage) error
+
+	SchemaModelsFn    func(groupID, appID, language string) ([]realm.SchemaModel, error)
+	GraphQLSchemaFn   func(groupID, appID string) (string, error)
+	TestDataSourceFn  func(groupID, appID, serviceID string) error
+	DefaultRuleFn     func(groupID, appID, serviceID string) (realm.DefaultRule, error)
+	SetDefaultRuleFn  func(groupID, appID, serviceID string, rule realm.DefaultRule) (realm.DefaultRule, error)
+	WebhooksFn        func(groupID, appID, serviceID string) ([]realm.Webhook, error)
+	DisableWebhookFn  func(groupID, appID, serviceID, webhookID string) error
+	EnableWebhookFn   func(groupID, appID, serviceID, webhookID string) error
+	EndpointsFn       func(groupID, appID string) ([]realm.Endpoint, error)
+	DisableEndpointFn func(groupID, appID, endpointID string) error
+	EnableEndpointFn  func(groupID, appID, endpointID string) error
+	SyncConfigFn      func(groupID, appID string) (realm.SyncConfig, error)
+	PauseSyncFn       func(groupID, appID string) error
+	ResumeSyncFn      func(groupID, appID string) error
 
 	AllTemplatesFn        func() ([]realm.Template, error)
 	ClientTemplateFn      func(groupID, appID, templateID string) (*zip.Reader, bool, error)
 	CompatibleTemplatesFn func(groupID, appID string) ([]realm.Template, error)
 
-	AllowedIPsFn      func(groupID, appID string) ([]realm.AllowedIP, error)
-	AllowedIPCreateFn func(groupID, appID, address, comment string, useCurrent bool) (realm.AllowedIP, error)
-	AllowedIPUpdateFn func(groupID, appID, allowedIPID, newAddress, newComment string) error
-	AllowedIPDeleteFn func(groupID, appID, allowedIPID string) error
-
-	StatusFn func() error
+	AllowedIPsFn                  func(groupID, appID string) ([]realm.AllowedIP, error)
+	AllowedIPCreateFn             func(groupID, appID, address, comment string, useCurrent bool) (realm.AllowedIP, error)
+	AllowedIPUpdateFn             func(groupID, appID, allowedIPID, newAddress, newComment string) error
+	AllowedIPDeleteFn             func(groupID, appID, allowedIPID string) error
+	AllowedRequestOriginsFn       func(groupID, appID string) ([]string, error)
+	UpdateAllowedRequestOriginsFn func(groupID, appID string, origins []string) error
+
+	StatusFn        func() error
+	ServerVersionFn func() (string, error)
+	DoRequestFn     func(method, path string, options api.RequestOptions) (*http.Response, error)
+	CloseFn         func() error
 }
 
 // Authenticate calls the mocked Authenticate implementation if provided,
@@ -86,6 +156,26 @@ func (rc RealmClient) Authenticate(publicAPIKey, privateAPIKey string) (realm.Se
 	return rc.Client.Authenticate(publicAPIKey, privateAPIKey)
 }
 
+// SaveSession calls the mocked SaveSession implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) SaveSession(session realm.Session) error {
+	if rc.SaveSessionFn != nil {
+		return rc.SaveSessionFn(session)
+	}
+	return rc.Client.SaveSession(session)
+}
+
+// ClearSession calls the mocked ClearSession implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ClearSession() error {
+	if rc.ClearSessionFn != nil {
+		return rc.ClearSessionFn()
+	}
+	return rc.Client.ClearSession()
+}
+
 // AuthProfile calls the mocked AuthProfile implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -96,6 +186,16 @@ func (rc RealmClient) AuthProfile() (realm.AuthProfile, error) {
 	return rc.Client.AuthProfile()
 }
 
+// RotateCredentials calls the mocked RotateCredentials implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) RotateCredentials(publicAPIKey, privateAPIKey string) error {
+	if rc.RotateCredentialsFn != nil {
+		return rc.RotateCredentialsFn(publicAPIKey, privateAPIKey)
+	}
+	return rc.Client.RotateCredentials(publicAPIKey, privateAPIKey)
+}
+
 // Export calls the mocked Export implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -106,6 +206,56 @@ func (rc RealmClient) Export(groupID, appID string, req realm.ExportRequest) (st
 	return rc.Client.Export(groupID, appID, req)
 }
 
+// ExportBytes calls the mocked ExportBytes implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportBytes(groupID, appID string, req realm.ExportRequest) (string, []byte, error) {
+	if rc.ExportBytesFn != nil {
+		return rc.ExportBytesFn(groupID, appID, req)
+	}
+	return rc.Client.ExportBytes(groupID, appID, req)
+}
+
+// ExportFiles calls the mocked ExportFiles implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportFiles(groupID, appID string, req realm.ExportRequest) (map[string][]byte, string, error) {
+	if rc.ExportFilesFn != nil {
+		return rc.ExportFilesFn(groupID, appID, req)
+	}
+	return rc.Client.ExportFiles(groupID, appID, req)
+}
+
+// ExportEachFile calls the mocked ExportEachFile implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportEachFile(groupID, appID string, req realm.ExportRequest, fn realm.ExportFileFunc) error {
+	if rc.ExportEachFileFn != nil {
+		return rc.ExportEachFileFn(groupID, appID, req, fn)
+	}
+	return rc.Client.ExportEachFile(groupID, appID, req, fn)
+}
+
+// ExportToTarGz calls the mocked ExportToTarGz implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportToTarGz(groupID, appID string, req realm.ExportRequest, w io.Writer) error {
+	if rc.ExportToTarGzFn != nil {
+		return rc.ExportToTarGzFn(groupID, appID, req, w)
+	}
+	return rc.Client.ExportToTarGz(groupID, appID, req, w)
+}
+
+// ExportWithManifest calls the mocked ExportWithManifest implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportWithManifest(groupID, appID string, req realm.ExportRequest) (realm.ExportManifest, []byte, error) {
+	if rc.ExportWithManifestFn != nil {
+		return rc.ExportWithManifestFn(groupID, appID, req)
+	}
+	return rc.Client.ExportWithManifest(groupID, appID, req)
+}
+
 // Import calls the mocked Import implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -116,6 +266,46 @@ func (rc RealmClient) Import(groupID, appID string, appData interface{}) error {
 	return rc.Client.Import(groupID, appID, appData)
 }
 
+// ImportWithOptions calls the mocked ImportWithOptions implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ImportWithOptions(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+	if rc.ImportWithOptionsFn != nil {
+		return rc.ImportWithOptionsFn(groupID, appID, appData, opts)
+	}
+	return rc.Client.ImportWithOptions(groupID, appID, appData, opts)
+}
+
+// ImportMany calls the mocked ImportMany implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ImportMany(groupID string, appData map[string]interface{}, opts realm.ImportOptions) error {
+	if rc.ImportManyFn != nil {
+		return rc.ImportManyFn(groupID, appData, opts)
+	}
+	return rc.Client.ImportMany(groupID, appData, opts)
+}
+
+// ImportAndVerify calls the mocked ImportAndVerify implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ImportAndVerify(groupID, appID string, appData interface{}, opts realm.ImportOptions) error {
+	if rc.ImportAndVerifyFn != nil {
+		return rc.ImportAndVerifyFn(groupID, appID, appData, opts)
+	}
+	return rc.Client.ImportAndVerify(groupID, appID, appData, opts)
+}
+
+// ImportYAML calls the mocked ImportYAML implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ImportYAML(groupID, appID string, data []byte, opts realm.ImportOptions) error {
+	if rc.ImportYAMLFn != nil {
+		return rc.ImportYAMLFn(groupID, appID, data, opts)
+	}
+	return rc.Client.ImportYAML(groupID, appID, data, opts)
+}
+
 // Diff calls the mocked Diff implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -126,6 +316,46 @@ func (rc RealmClient) Diff(groupID, appID string, appData interface{}) ([]string
 	return rc.Client.Diff(groupID, appID, appData)
 }
 
+// DiffDeployment calls the mocked DiffDeployment implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DiffDeployment(groupID, appID, deploymentID string, appData interface{}) ([]string, error) {
+	if rc.DiffDeploymentFn != nil {
+		return rc.DiffDeploymentFn(groupID, appID, deploymentID, appData)
+	}
+	return rc.Client.DiffDeployment(groupID, appID, deploymentID, appData)
+}
+
+// DiffWithWarnings calls the mocked DiffWithWarnings implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DiffWithWarnings(groupID, appID string, appData interface{}) (realm.DiffResult, error) {
+	if rc.DiffWithWarningsFn != nil {
+		return rc.DiffWithWarningsFn(groupID, appID, appData)
+	}
+	return rc.Client.DiffWithWarnings(groupID, appID, appData)
+}
+
+// DiffGrouped calls the mocked DiffGrouped implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DiffGrouped(groupID, appID string, appData interface{}) (map[string][]string, error) {
+	if rc.DiffGroupedFn != nil {
+		return rc.DiffGroupedFn(groupID, appID, appData)
+	}
+	return rc.Client.DiffGrouped(groupID, appID, appData)
+}
+
+// DestructiveChanges calls the mocked DestructiveChanges implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DestructiveChanges(groupID, appID string, appData []byte, strategy string) ([]string, error) {
+	if rc.DestructiveChangesFn != nil {
+		return rc.DestructiveChangesFn(groupID, appID, appData, strategy)
+	}
+	return rc.Client.DestructiveChanges(groupID, appID, appData, strategy)
+}
+
 // CreateApp calls the mocked CreateApp implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -146,6 +376,36 @@ func (rc RealmClient) DeleteApp(groupID, appID string) error {
 	return rc.Client.DeleteApp(groupID, appID)
 }
 
+// PreDeleteCheck calls the mocked PreDeleteCheck implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) PreDeleteCheck(groupID, appID string) ([]string, error) {
+	if rc.PreDeleteCheckFn != nil {
+		return rc.PreDeleteCheckFn(groupID, appID)
+	}
+	return rc.Client.PreDeleteCheck(groupID, appID)
+}
+
+// DisableApp calls the mocked DisableApp implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DisableApp(groupID, appID string) error {
+	if rc.DisableAppFn != nil {
+		return rc.DisableAppFn(groupID, appID)
+	}
+	return rc.Client.DisableApp(groupID, appID)
+}
+
+// EnableApp calls the mocked EnableApp implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) EnableApp(groupID, appID string) error {
+	if rc.EnableAppFn != nil {
+		return rc.EnableAppFn(groupID, appID)
+	}
+	return rc.Client.EnableApp(groupID, appID)
+}
+
 // FindApp calls the mocked FindApp implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -166,6 +426,26 @@ func (rc RealmClient) FindApps(filter realm.AppFilter) ([]realm.App, error) {
 	return rc.Client.FindApps(filter)
 }
 
+// ListAllApps calls the mocked ListAllApps implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ListAllApps() (map[string][]realm.App, error) {
+	if rc.ListAllAppsFn != nil {
+		return rc.ListAllAppsFn()
+	}
+	return rc.Client.ListAllApps()
+}
+
+// FindAppByClientAppID calls the mocked FindAppByClientAppID implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) FindAppByClientAppID(groupID, clientAppID string) (realm.App, error) {
+	if rc.FindAppByClientAppIDFn != nil {
+		return rc.FindAppByClientAppIDFn(groupID, clientAppID)
+	}
+	return rc.Client.FindAppByClientAppID(groupID, clientAppID)
+}
+
 // AppDescription calls the mocked AppDescription implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -176,6 +456,16 @@ func (rc RealmClient) AppDescription(groupID, appID string) (realm.AppDescriptio
 	return rc.Client.AppDescription(groupID, appID)
 }
 
+// FetchResource calls the mocked FetchResource implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) FetchResource(groupID, appID, resourcePath string) (json.RawMessage, error) {
+	if rc.FetchResourceFn != nil {
+		return rc.FetchResourceFn(groupID, appID, resourcePath)
+	}
+	return rc.Client.FetchResource(groupID, appID, resourcePath)
+}
+
 // CreateDraft calls the mocked CreateDraft implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -226,6 +516,26 @@ func (rc RealmClient) Draft(groupID, appID string) (realm.AppDraft, error) {
 	return rc.Client.Draft(groupID, appID)
 }
 
+// DeployConfig calls the mocked DeployConfig implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DeployConfig(groupID, appID string) (realm.DeployConfig, error) {
+	if rc.DeployConfigFn != nil {
+		return rc.DeployConfigFn(groupID, appID)
+	}
+	return rc.Client.DeployConfig(groupID, appID)
+}
+
+// UpdateDeployConfig calls the mocked UpdateDeployConfig implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateDeployConfig(groupID, appID string, config realm.DeployConfig) error {
+	if rc.UpdateDeployConfigFn != nil {
+		return rc.UpdateDeployConfigFn(groupID, appID, config)
+	}
+	return rc.Client.UpdateDeployConfig(groupID, appID, config)
+}
+
 // Deployment calls the mocked Deployment implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -236,6 +546,16 @@ func (rc RealmClient) Deployment(groupID, appID, deploymentID string) (realm.App
 	return rc.Client.Deployment(groupID, appID, deploymentID)
 }
 
+// WaitForDeploy calls the mocked WaitForDeploy implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) WaitForDeploy(groupID, appID, deploymentID string, timeout time.Duration) error {
+	if rc.WaitForDeployFn != nil {
+		return rc.WaitForDeployFn(groupID, appID, deploymentID, timeout)
+	}
+	return rc.Client.WaitForDeploy(groupID, appID, deploymentID, timeout)
+}
+
 // DependenciesStatus calls the mocked DependenciesStatus implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -246,6 +566,66 @@ func (rc RealmClient) DependenciesStatus(groupID, appID string) (realm.Dependenc
 	return rc.Client.DependenciesStatus(groupID, appID)
 }
 
+// WaitForDependencies calls the mocked WaitForDependencies implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) WaitForDependencies(groupID, appID string, timeout time.Duration, onStatus func(status realm.DependenciesStatus)) error {
+	if rc.WaitForDependenciesFn != nil {
+		return rc.WaitForDependenciesFn(groupID, appID, timeout, onStatus)
+	}
+	return rc.Client.WaitForDependencies(groupID, appID, timeout, onStatus)
+}
+
+// AuthProviders calls the mocked AuthProviders implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) AuthProviders(groupID, appID string) ([]realm.AuthProvider, error) {
+	if rc.AuthProvidersFn != nil {
+		return rc.AuthProvidersFn(groupID, appID)
+	}
+	return rc.Client.AuthProviders(groupID, appID)
+}
+
+// DisableAuthProvider calls the mocked DisableAuthProvider implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DisableAuthProvider(groupID, appID, providerID string) error {
+	if rc.DisableAuthProviderFn != nil {
+		return rc.DisableAuthProviderFn(groupID, appID, providerID)
+	}
+	return rc.Client.DisableAuthProvider(groupID, appID, providerID)
+}
+
+// EnableAuthProvider calls the mocked EnableAuthProvider implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) EnableAuthProvider(groupID, appID, providerID string) error {
+	if rc.EnableAuthProviderFn != nil {
+		return rc.EnableAuthProviderFn(groupID, appID, providerID)
+	}
+	return rc.Client.EnableAuthProvider(groupID, appID, providerID)
+}
+
+// CustomUserData calls the mocked CustomUserData implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) CustomUserData(groupID, appID string) (realm.CustomUserDataConfig, error) {
+	if rc.CustomUserDataFn != nil {
+		return rc.CustomUserDataFn(groupID, appID)
+	}
+	return rc.Client.CustomUserData(groupID, appID)
+}
+
+// UpdateCustomUserData calls the mocked UpdateCustomUserData implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateCustomUserData(groupID, appID string, config realm.CustomUserDataConfig) error {
+	if rc.UpdateCustomUserDataFn != nil {
+		return rc.UpdateCustomUserDataFn(groupID, appID, config)
+	}
+	return rc.Client.UpdateCustomUserData(groupID, appID, config)
+}
+
 // CreateAPIKey calls the mocked CreateAPIKey implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -296,6 +676,26 @@ func (rc RealmClient) UpdateSecret(groupID, appID, secretID, name, value string)
 	return rc.Client.UpdateSecret(groupID, appID, secretID, name, value)
 }
 
+// Values calls the mocked Values implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Values(groupID, appID string) ([]realm.Value, error) {
+	if rc.ValuesFn != nil {
+		return rc.ValuesFn(groupID, appID)
+	}
+	return rc.Client.Values(groupID, appID)
+}
+
+// FindValue calls the mocked FindValue implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) FindValue(groupID, appID, name string) (realm.Value, error) {
+	if rc.FindValueFn != nil {
+		return rc.FindValueFn(groupID, appID, name)
+	}
+	return rc.Client.FindValue(groupID, appID, name)
+}
+
 // CreateUser calls the mocked CreateUser implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -356,6 +756,26 @@ func (rc RealmClient) RevokeUserSessions(groupID, appID, userID string) error {
 	return rc.Client.RevokeUserSessions(groupID, appID, userID)
 }
 
+// ConfirmUser calls the mocked ConfirmUser implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ConfirmUser(groupID, appID, email string) error {
+	if rc.ConfirmUserFn != nil {
+		return rc.ConfirmUserFn(groupID, appID, email)
+	}
+	return rc.Client.ConfirmUser(groupID, appID, email)
+}
+
+// ResetUserPassword calls the mocked ResetUserPassword implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ResetUserPassword(groupID, appID, email, password string) error {
+	if rc.ResetUserPasswordFn != nil {
+		return rc.ResetUserPasswordFn(groupID, appID, email, password)
+	}
+	return rc.Client.ResetUserPassword(groupID, appID, email, password)
+}
+
 // ExportDependencies calls the mocked ExportDependencies implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -396,6 +816,16 @@ func (rc RealmClient) DiffDependencies(groupID, appID, uploadPath string) (realm
 	return rc.Client.DiffDependencies(groupID, appID, uploadPath)
 }
 
+// Dependencies calls the mocked Dependencies implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Dependencies(groupID, appID string) ([]realm.DependencyData, error) {
+	if rc.DependenciesFn != nil {
+		return rc.DependenciesFn(groupID, appID)
+	}
+	return rc.Client.Dependencies(groupID, appID)
+}
+
 // HostingAssets calls the mocked HostingAssets implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -456,6 +886,26 @@ func (rc RealmClient) Functions(groupID, appID string) ([]realm.Function, error)
 	return rc.Client.Functions(groupID, appID)
 }
 
+// ExportFunction calls the mocked ExportFunction implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ExportFunction(groupID, appID, functionName string) (string, error) {
+	if rc.ExportFunctionFn != nil {
+		return rc.ExportFunctionFn(groupID, appID, functionName)
+	}
+	return rc.Client.ExportFunction(groupID, appID, functionName)
+}
+
+// UpdateFunction calls the mocked UpdateFunction implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateFunction(groupID, appID, functionName, source string) error {
+	if rc.UpdateFunctionFn != nil {
+		return rc.UpdateFunctionFn(groupID, appID, functionName, source)
+	}
+	return rc.Client.UpdateFunction(groupID, appID, functionName, source)
+}
+
 // AppDebugExecuteFunction calls the mocked AppDebugExecuteFunction implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -476,6 +926,76 @@ func (rc RealmClient) Logs(groupID, appID string, opts realm.LogsOptions) (realm
 	return rc.Client.Logs(groupID, appID, opts)
 }
 
+// LogsPage calls the mocked LogsPage implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) LogsPage(groupID, appID string, opts realm.LogsOptions, cursor realm.LogsCursor) (realm.Logs, realm.LogsCursor, error) {
+	if rc.LogsPageFn != nil {
+		return rc.LogsPageFn(groupID, appID, opts, cursor)
+	}
+	return rc.Client.LogsPage(groupID, appID, opts, cursor)
+}
+
+// TriggerExecutions calls the mocked TriggerExecutions implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) TriggerExecutions(groupID, appID, triggerID string, opts realm.TriggerExecutionsOptions) ([]realm.TriggerExecution, error) {
+	if rc.TriggerExecutionsFn != nil {
+		return rc.TriggerExecutionsFn(groupID, appID, triggerID, opts)
+	}
+	return rc.Client.TriggerExecutions(groupID, appID, triggerID, opts)
+}
+
+// LogForwarders calls the mocked LogForwarders implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) LogForwarders(groupID, appID string) ([]realm.LogForwarder, error) {
+	if rc.LogForwardersFn != nil {
+		return rc.LogForwardersFn(groupID, appID)
+	}
+	return rc.Client.LogForwarders(groupID, appID)
+}
+
+// UpsertLogForwarder calls the mocked UpsertLogForwarder implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpsertLogForwarder(groupID, appID string, forwarder realm.LogForwarder) (realm.LogForwarder, error) {
+	if rc.UpsertLogForwarderFn != nil {
+		return rc.UpsertLogForwarderFn(groupID, appID, forwarder)
+	}
+	return rc.Client.UpsertLogForwarder(groupID, appID, forwarder)
+}
+
+// Schemas calls the mocked Schemas implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Schemas(groupID, appID string) ([]realm.Schema, error) {
+	if rc.SchemasFn != nil {
+		return rc.SchemasFn(groupID, appID)
+	}
+	return rc.Client.Schemas(groupID, appID)
+}
+
+// FindSchema calls the mocked FindSchema implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) FindSchema(groupID, appID, serviceID, database, collection string) (realm.Schema, error) {
+	if rc.FindSchemaFn != nil {
+		return rc.FindSchemaFn(groupID, appID, serviceID, database, collection)
+	}
+	return rc.Client.FindSchema(groupID, appID, serviceID, database, collection)
+}
+
+// UpsertSchema calls the mocked UpsertSchema implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpsertSchema(groupID, appID, serviceID, database, collection string, schema json.RawMessage) error {
+	if rc.UpsertSchemaFn != nil {
+		return rc.UpsertSchemaFn(groupID, appID, serviceID, database, collection, schema)
+	}
+	return rc.Client.UpsertSchema(groupID, appID, serviceID, database, collection, schema)
+}
+
 // SchemaModels calls the mocked SchemaModels implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -486,6 +1006,136 @@ func (rc RealmClient) SchemaModels(groupID, appID, language string) ([]realm.Sch
 	return rc.Client.SchemaModels(groupID, appID, language)
 }
 
+// GraphQLSchema calls the mocked GraphQLSchema implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) GraphQLSchema(groupID, appID string) (string, error) {
+	if rc.GraphQLSchemaFn != nil {
+		return rc.GraphQLSchemaFn(groupID, appID)
+	}
+	return rc.Client.GraphQLSchema(groupID, appID)
+}
+
+// TestDataSource calls the mocked TestDataSource implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) TestDataSource(groupID, appID, serviceID string) error {
+	if rc.TestDataSourceFn != nil {
+		return rc.TestDataSourceFn(groupID, appID, serviceID)
+	}
+	return rc.Client.TestDataSource(groupID, appID, serviceID)
+}
+
+// DefaultRule calls the mocked DefaultRule implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DefaultRule(groupID, appID, serviceID string) (realm.DefaultRule, error) {
+	if rc.DefaultRuleFn != nil {
+		return rc.DefaultRuleFn(groupID, appID, serviceID)
+	}
+	return rc.Client.DefaultRule(groupID, appID, serviceID)
+}
+
+// SetDefaultRule calls the mocked SetDefaultRule implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) SetDefaultRule(groupID, appID, serviceID string, rule realm.DefaultRule) (realm.DefaultRule, error) {
+	if rc.SetDefaultRuleFn != nil {
+		return rc.SetDefaultRuleFn(groupID, appID, serviceID, rule)
+	}
+	return rc.Client.SetDefaultRule(groupID, appID, serviceID, rule)
+}
+
+// Webhooks calls the mocked Webhooks implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Webhooks(groupID, appID, serviceID string) ([]realm.Webhook, error) {
+	if rc.WebhooksFn != nil {
+		return rc.WebhooksFn(groupID, appID, serviceID)
+	}
+	return rc.Client.Webhooks(groupID, appID, serviceID)
+}
+
+// DisableWebhook calls the mocked DisableWebhook implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DisableWebhook(groupID, appID, serviceID, webhookID string) error {
+	if rc.DisableWebhookFn != nil {
+		return rc.DisableWebhookFn(groupID, appID, serviceID, webhookID)
+	}
+	return rc.Client.DisableWebhook(groupID, appID, serviceID, webhookID)
+}
+
+// EnableWebhook calls the mocked EnableWebhook implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) EnableWebhook(groupID, appID, serviceID, webhookID string) error {
+	if rc.EnableWebhookFn != nil {
+		return rc.EnableWebhookFn(groupID, appID, serviceID, webhookID)
+	}
+	return rc.Client.EnableWebhook(groupID, appID, serviceID, webhookID)
+}
+
+// Endpoints calls the mocked Endpoints implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Endpoints(groupID, appID string) ([]realm.Endpoint, error) {
+	if rc.EndpointsFn != nil {
+		return rc.EndpointsFn(groupID, appID)
+	}
+	return rc.Client.Endpoints(groupID, appID)
+}
+
+// DisableEndpoint calls the mocked DisableEndpoint implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DisableEndpoint(groupID, appID, endpointID string) error {
+	if rc.DisableEndpointFn != nil {
+		return rc.DisableEndpointFn(groupID, appID, endpointID)
+	}
+	return rc.Client.DisableEndpoint(groupID, appID, endpointID)
+}
+
+// EnableEndpoint calls the mocked EnableEndpoint implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) EnableEndpoint(groupID, appID, endpointID string) error {
+	if rc.EnableEndpointFn != nil {
+		return rc.EnableEndpointFn(groupID, appID, endpointID)
+	}
+	return rc.Client.EnableEndpoint(groupID, appID, endpointID)
+}
+
+// SyncConfig calls the mocked SyncConfig implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) SyncConfig(groupID, appID string) (realm.SyncConfig, error) {
+	if rc.SyncConfigFn != nil {
+		return rc.SyncConfigFn(groupID, appID)
+	}
+	return rc.Client.SyncConfig(groupID, appID)
+}
+
+// PauseSync calls the mocked PauseSync implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) PauseSync(groupID, appID string) error {
+	if rc.PauseSyncFn != nil {
+		return rc.PauseSyncFn(groupID, appID)
+	}
+	return rc.Client.PauseSync(groupID, appID)
+}
+
+// ResumeSync calls the mocked ResumeSync implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ResumeSync(groupID, appID string) error {
+	if rc.ResumeSyncFn != nil {
+		return rc.ResumeSyncFn(groupID, appID)
+	}
+	return rc.Client.ResumeSync(groupID, appID)
+}
+
 // AllTemplates calls the mocked AllTemplates implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -556,6 +1206,26 @@ func (rc RealmClient) AllowedIPDelete(groupID, appID, allowedIPID string) error
 	return rc.Client.AllowedIPDelete(groupID, appID, allowedIPID)
 }
 
+// AllowedRequestOrigins calls the mocked AllowedRequestOrigins implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) AllowedRequestOrigins(groupID, appID string) ([]string, error) {
+	if rc.AllowedRequestOriginsFn != nil {
+		return rc.AllowedRequestOriginsFn(groupID, appID)
+	}
+	return rc.Client.AllowedRequestOrigins(groupID, appID)
+}
+
+// UpdateAllowedRequestOrigins calls the mocked UpdateAllowedRequestOrigins implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateAllowedRequestOrigins(groupID, appID string, origins []string) error {
+	if rc.UpdateAllowedRequestOriginsFn != nil {
+		return rc.UpdateAllowedRequestOriginsFn(groupID, appID, origins)
+	}
+	return rc.Client.UpdateAllowedRequestOrigins(groupID, appID, origins)
+}
+
 // Status calls the mocked Status implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -565,3 +1235,33 @@ func (rc RealmClient) Status() error {
 	}
 	return rc.Client.Status()
 }
+
+// ServerVersion calls the mocked ServerVersion implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) ServerVersion() (string, error) {
+	if rc.ServerVersionFn != nil {
+		return rc.ServerVersionFn()
+	}
+	return rc.Client.ServerVersion()
+}
+
+// DoRequest calls the mocked DoRequest implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DoRequest(method, path string, options api.RequestOptions) (*http.Response, error) {
+	if rc.DoRequestFn != nil {
+		return rc.DoRequestFn(method, path, options)
+	}
+	return rc.Client.DoRequest(method, path, options)
+}
+
+// Close calls the mocked Close implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Close() error {
+	if rc.CloseFn != nil {
+		return rc.CloseFn()
+	}
+	return rc.Client.Close()
+}